@@ -114,9 +114,30 @@ func (m *ActionBarModel) ToggleFocus() *ActionBarModel {
 	return m
 }
 
+/*
+Returns the first of the actions whose shortcut is the given keyboard shortcut string, or nil if there isn't one.
+Actions whose containers handle their shortcuts as keys (see con.KeyHandledAction) are left out
+*/
+func (m ActionBarModel) GetShortcutAction(shortcut string) con.Action {
+	for _, action := range m.GetActions() {
+		if shortcut != "" && action.GetShortcut() == shortcut && !con.IsHandledAsKey(action) {
+			return action
+		}
+	}
+	return nil
+}
+
+/*
+Returns true if the given keyboard shortcut string runs one of the actions or undoes or redoes one
+*/
+func (m ActionBarModel) IsShortcut(shortcut string) bool {
+	return m.actionStack.IsActionStackKey(shortcut) || m.GetShortcutAction(shortcut) != nil
+}
+
 /*
 Handles the given keyboard shortcut string, whether it's an action's
-shortcut or a shortcut for the action bar itself
+shortcut or a shortcut for the action bar itself. Actions whose containers
+handle their shortcuts as keys (see con.KeyHandledAction) aren't executed
 */
 func (m *ActionBarModel) HandleShortcuts(shortcut string) *ActionBarModel {
 	if m.actionStack.IsActionStackKey(shortcut) {
@@ -125,7 +146,7 @@ func (m *ActionBarModel) HandleShortcuts(shortcut string) *ActionBarModel {
 	}
 
 	for _, action := range m.actionsDelegate() {
-		if shortcut == action.GetShortcut() {
+		if shortcut == action.GetShortcut() && !con.IsHandledAsKey(action) {
 			m.actionStack.Execute(action)
 		}
 	}
@@ -193,6 +214,8 @@ func (m ActionBarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				for _, action := range m.actionsDelegate() {
 					if action.GetName() == m.GetInputValue() {
 						m.actionStack.Execute(action)
+						// the containers lay themselves out again to show the action's changes
+						cmds = append(cmds, con.Relayout)
 						m.input.Reset()
 						m.actionListModel.UpdateSuggestedActionsFromInput(
 							m.GetInputValue(),
@@ -214,6 +237,10 @@ func (m ActionBarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 	case tea.WindowSizeMsg:
 		m.input.Width = msg.Width
+	case con.ActionExecutedMsg:
+		// the action was already executed elsewhere, so it only needs to be recorded so that it can be undone
+		m.actionStack.Push(msg.Action)
+		return m, nil
 	}
 
 	// keep the actionListModel from searching for matching suggestions if there's no input
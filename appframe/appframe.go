@@ -1,7 +1,10 @@
 package appframe
 
 import (
+	"fmt"
+
 	actionbar "github.com/argotnaut/vanitea/actionbar"
+	"github.com/argotnaut/vanitea/colors"
 	con "github.com/argotnaut/vanitea/container"
 	lc "github.com/argotnaut/vanitea/linearcontainer"
 	navshell "github.com/argotnaut/vanitea/navshell"
@@ -11,6 +14,11 @@ import (
 	"github.com/kevm/bubbleo/navstack"
 )
 
+// Shown instead of the AppFrame's contents when the window is smaller than their minimum size
+const TOO_SMALL_MESSAGE = "terminal too small (need %dx%d)"
+
+var TOO_SMALL_STYLE = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(colors.TOO_SMALL_MESSAGE))
+
 /*
 The root model for a TUI program that includes a navstack and an actionbar/command-palette
 */
@@ -23,6 +31,15 @@ type AppFrame struct {
 		ActionBar is focused
 	*/
 	actionBarIsFocused bool
+	/*
+		The size of the window
+	*/
+	size tea.WindowSizeMsg
+	/*
+		The commands returned when the main container was pushed onto the navstack (its
+		components' Init functions and the lifecycle messages sent when it was first laid out)
+	*/
+	initCmd tea.Cmd
 }
 
 /*
@@ -70,7 +87,8 @@ func NewAppFrame(appName string, components []*con.Component) (output AppFrame)
 	)
 	output.actionBar.Blur()
 
-	navshell.GetNavShell().Navstack.Push(
+	// pushing the container initializes its components, so their commands are kept for Init to return
+	output.initCmd = navshell.GetNavShell().Navstack.Push(
 		navstack.NavigationItem{
 			Model: container,
 			Title: appName,
@@ -84,7 +102,7 @@ Call the Init functions of all the child components (including the
 actionBar, which will need it for the cursor to blink)
 */
 func (m AppFrame) Init() tea.Cmd {
-	return m.actionBar.Init()
+	return tea.Batch(m.initCmd, m.actionBar.Init())
 }
 
 func (m AppFrame) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -116,18 +134,26 @@ func (m AppFrame) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.actionBarIsFocused {
 				return updateActionBar(message)
 			} else {
+				if m.actionBar.IsShortcut(msg.String()) {
+					// the containers lay themselves out again to show the changes of the action that was run, undone or redone
+					cmds = append(cmds, con.Relayout)
+				}
 				m.actionBar.HandleShortcuts(msg.String())
-				cmd := navshell.UpdateSingleton(message)
-				return m, cmd
+				cmds = append(cmds, navshell.UpdateSingleton(message))
+				return m, tea.Batch(cmds...)
 			}
 		}
+	case con.ActionExecutedMsg:
+		// only the action bar keeps track of executed actions
+		return updateActionBar(message)
 	case tea.WindowSizeMsg:
 		// The action bar isn't part of the main container because it shouldn't
 		// be focusable except by the above key combination, so the height
 		// of this tea.WindowSizeMsg is reduced to make room below for the
 		// actionBar, which will always have a height of 1
+		m.size = msg
 		message = tea.WindowSizeMsg{
-			Height: max(0, msg.Height-1-lipgloss.Height(navshell.GetNavShell().Breadcrumb.View())),
+			Height: max(0, msg.Height-getChromeHeight()),
 			Width:  msg.Width,
 		}
 	}
@@ -140,7 +166,47 @@ func (m AppFrame) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+/*
+Returns the number of rows taken up by the breadcrumb and the action bar
+*/
+func getChromeHeight() int {
+	return 1 + lipgloss.Height(navshell.GetNavShell().Breadcrumb.View())
+}
+
+/*
+Returns the smallest window the AppFrame's contents fit in: the minimum size of the model at the top
+of the nav stack (if it reports one, like containers do, see con.ContentSizer), plus the rows taken up
+by the breadcrumb and the action bar
+*/
+func (m AppFrame) GetMinimumSize() tea.WindowSizeMsg {
+	output := tea.WindowSizeMsg{Height: getChromeHeight()}
+	if sizer, isSizer := navshell.GetNavShell().Navstack.Top().Model.(con.ContentSizer); isSizer {
+		minimum := sizer.GetContentSize(tea.WindowSizeMsg{
+			Width:  m.size.Width,
+			Height: max(0, m.size.Height-output.Height),
+		}).Minimum
+		output.Width = minimum.Width
+		output.Height += minimum.Height
+	}
+	return output
+}
+
+/*
+Renders the message shown instead of the AppFrame's contents when the window is too small for them
+*/
+func (m AppFrame) viewTooSmall(minimum tea.WindowSizeMsg) string {
+	message := TOO_SMALL_STYLE.Width(m.size.Width).MaxHeight(m.size.Height).Align(lipgloss.Center).
+		Render(fmt.Sprintf(TOO_SMALL_MESSAGE, minimum.Width, minimum.Height))
+	return lipgloss.Place(m.size.Width, m.size.Height, lipgloss.Center, lipgloss.Center, message)
+}
+
 func (m AppFrame) View() string {
+	// the window size isn't known until the first tea.WindowSizeMsg
+	if m.size.Width > 0 || m.size.Height > 0 {
+		if minimum := m.GetMinimumSize(); m.size.Width < minimum.Width || m.size.Height < minimum.Height {
+			return m.viewTooSmall(minimum)
+		}
+	}
 	return utils.PlaceStacked(
 		navshell.GetNavShell().View()+"\n",
 		m.actionBar.View(),
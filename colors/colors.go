@@ -7,10 +7,12 @@ const (
 	ACTIONS_LIST_DESCRIPTION     = "241"     // dark grey
 	ACTIONS_LIST_DIVIDER         = "60"      // light grey
 	FOCUSED_BORDER               = "69"      // lavender
+	CONTAINS_FOCUS_BORDER        = "61"      // pale purple
 	UNFOCUSED_BORDER             = "#AAAAAA" // light grey
 	SELECTLIST_DESELECTED        = "241"     // dark grey
 	SELECTLIST_SELECTED          = "69"      // lavender
 	BREADCRUMB_ITEM_BACKGROUND   = "#363137" // dark grey
 	BREADCRUMB_BACKGROUND        = "#444244" // dark grey
 	BREADCRUMB_FOREGROUND        = "#e5d9ee" // lavender-white
+	TOO_SMALL_MESSAGE            = "203"     // salmon
 )
@@ -112,14 +112,18 @@ func (m ComponentList) getAlternatingComponents(startIdx int) (output []*con.Com
 	return
 }
 
+/*
+Returns the border style the given component is currently rendered with
+*/
+func (m ComponentList) getComponentStyle(component *con.Component) lipgloss.Style {
+	return component.GetCurrentBorderStyle(component == m.GetFocusedComponent())
+}
+
 func (m ComponentList) resizeComponentModelForStyle(component *con.Component, size tea.WindowSizeMsg) tea.Cmd {
 	if component == nil {
 		return nil
 	}
-	componentStyle := component.GetBorderStyle()
-	if component == m.GetFocusedComponent() {
-		componentStyle = component.GetFocusBorderStyle()
-	}
+	componentStyle := m.getComponentStyle(component)
 	model, cmd := component.GetModel().Update(tea.WindowSizeMsg{
 		Width:  size.Width - componentStyle.GetHorizontalFrameSize(),
 		Height: size.Height - componentStyle.GetVerticalFrameSize(),
@@ -143,7 +147,7 @@ func (m ComponentList) renderForStyle(component *con.Component) string {
 func (m ComponentList) Init() tea.Cmd {
 	var cmds []tea.Cmd
 	for _, component := range m.components {
-		cmds = append(cmds, component.GetModel().Init())
+		cmds = append(cmds, component.Init())
 	}
 	return tea.Batch(cmds...)
 }
@@ -167,13 +171,27 @@ func (m *ComponentList) handleKeyMapKey(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+/*
+Routes the given tea.MouseMsg to the component under the cursor (with its
+coordinates translated into that component's space) and, if the message is
+a click, moves focus to that component
+*/
+func (m *ComponentList) handleMouseMsg(msg tea.MouseMsg) tea.Cmd {
+	target := con.GetComponentAt(m.GetComponents(), msg.X, msg.Y)
+	if target == nil {
+		return nil
+	}
+	if con.IsFocusingMouseMsg(msg) {
+		m.SetFocusIndex(slices.Index(m.GetComponents(), target))
+	}
+	return target.Update(target.ToLocalMouseMsg(msg))
+}
+
 func (m ComponentList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	updateComponent := func(component *con.Component, msg tea.Msg) tea.Cmd {
-		model, cmd := component.GetModel().Update(msg)
-		component.SetModel(model)
-		return cmd
+		return component.Update(msg)
 	}
 	resizeComponent := func(component *con.Component) tea.Cmd {
 		return m.resizeComponentModelForStyle(component, tea.WindowSizeMsg{Width: m.size.Width, Height: 40})
@@ -186,7 +204,12 @@ func (m ComponentList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if focusedComponent != nil && keyMapResult == nil {
 			cmds = append(cmds, focusedComponent.Update(msg))
 		}
+		m.layoutComponents()
 		return m, tea.Batch(cmds...)
+	case tea.MouseMsg:
+		cmd := m.handleMouseMsg(msg)
+		m.layoutComponents()
+		return m, cmd
 	case tea.WindowSizeMsg:
 		m.size = msg
 	}
@@ -197,6 +220,7 @@ func (m ComponentList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			resizeComponent(component),
 		)
 	}
+	m.layoutComponents()
 
 	return m, tea.Batch(cmds...)
 }
@@ -216,10 +240,31 @@ func joinViewsVertically(strs ...string) string {
 	)
 }
 
-func (m ComponentList) viewWithComponentRenderer(renderer func(*con.Component) string) string {
+/*
+A component's rendering, cropped to the part of it that fits in the list's view, and the row of
+the view it starts at
+*/
+type placedComponent struct {
+	component *con.Component
+	view      string
+	y         int
+}
+
+/*
+Renders the components that fit in the list's view with the given renderer, around the focused
+component, and returns them from the top of the view to the bottom
+*/
+func (m ComponentList) placeComponents(renderer func(*con.Component) string) []placedComponent {
 	renderedSpaceUpperBound := m.focusedComponentPosition
 	renderedSpaceLowerBound := renderedSpaceUpperBound
-	joinedViews := ""
+
+	// the components above the focused one, from the nearest to the farthest, and the ones from the focused one down
+	var above, below []placedComponent
+	place := func(placed *[]placedComponent, component *con.Component, view string) {
+		if view != "" {
+			*placed = append(*placed, placedComponent{component: component, view: view})
+		}
+	}
 
 	components := m.getAlternatingComponents(m.focusedIndex)
 	for i, component := range components {
@@ -229,19 +274,13 @@ func (m ComponentList) viewWithComponentRenderer(renderer func(*con.Component) s
 		item := renderer(component)
 
 		if i == 0 {
-			joinedViews = item
+			place(&below, component, item)
 			renderedSpaceLowerBound += lipgloss.Height(item)
 		} else if (i % 2) == 0 {
-			joinedViews = joinViewsVertically(
-				limitHeight(item, renderedSpaceUpperBound),
-				joinedViews,
-			)
+			place(&above, component, limitHeight(item, renderedSpaceUpperBound))
 			renderedSpaceUpperBound -= lipgloss.Height(item)
 		} else {
-			joinedViews = joinViewsVertically(
-				joinedViews,
-				limitHeight(item, m.size.Height-renderedSpaceLowerBound),
-			)
+			place(&below, component, limitHeight(item, m.size.Height-renderedSpaceLowerBound))
 			renderedSpaceLowerBound += lipgloss.Height(item)
 		}
 
@@ -250,7 +289,40 @@ func (m ComponentList) viewWithComponentRenderer(renderer func(*con.Component) s
 		}
 	}
 
-	return joinedViews
+	slices.Reverse(above)
+	output := append(above, below...)
+	y := 0
+	for i := range output {
+		output[i].y = y
+		y += lipgloss.Height(output[i].view)
+	}
+	return output
+}
+
+func (m ComponentList) viewWithComponentRenderer(renderer func(*con.Component) string) string {
+	var views []string
+	for _, placed := range m.placeComponents(renderer) {
+		views = append(views, placed.view)
+	}
+	return joinViewsVertically(views...)
+}
+
+/*
+Records where each component is shown in the list's view (cropped to the part of it that's
+visible, or nowhere if it isn't shown) so that mouse messages can be routed to it
+*/
+func (m ComponentList) layoutComponents() {
+	bounds := map[*con.Component]con.Rectangle{}
+	for _, placed := range m.placeComponents(m.renderForStyle) {
+		bounds[placed.component] = con.Rectangle{
+			Y:      placed.y,
+			Width:  min(placed.component.GetSize().Width, m.size.Width),
+			Height: max(0, min(lipgloss.Height(placed.view), m.size.Height-placed.y)),
+		}
+	}
+	for _, component := range m.GetComponents() {
+		component.SetLayout(bounds[component], m.getComponentStyle(component))
+	}
 }
 
 func (m ComponentList) View() string {
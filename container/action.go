@@ -2,6 +2,8 @@ package container
 
 import (
 	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 /*
@@ -37,6 +39,42 @@ type Action interface {
 
 type Actions []Action
 
+/*
+Implemented by Actions whose shortcut is handled as a key by the container that provides them,
+so that whatever runs Actions by their shortcuts (like the action bar) doesn't run them again
+*/
+type KeyHandledAction interface {
+	Action
+	IsHandledAsKey() bool
+}
+
+/*
+Returns true if the given Action's shortcut is handled as a key by the container that provides it
+(see KeyHandledAction)
+*/
+func IsHandledAsKey(action Action) bool {
+	keyHandled, isKeyHandled := action.(KeyHandledAction)
+	return isKeyHandled && keyHandled.IsHandledAsKey()
+}
+
+/*
+A message reporting an Action that has already been executed (by a mouse drag, for
+instance), so that whatever keeps track of executed Actions can record it and
+allow it to be undone
+*/
+type ActionExecutedMsg struct {
+	Action Action
+}
+
+/*
+Returns a tea.Cmd that reports the given (already executed) Action
+*/
+func ReportExecutedAction(action Action) tea.Cmd {
+	return func() tea.Msg {
+		return ActionExecutedMsg{Action: action}
+	}
+}
+
 func (actions Actions) Names() (output []string) {
 	for _, action := range actions {
 		output = append(output, action.GetName())
@@ -54,6 +92,8 @@ type DefaultAction struct {
 	target      *Component
 	execute     func(*Component)
 	undo        func(*Component)
+	// whether the container providing the action handles its shortcut as a key (see KeyHandledAction)
+	handledAsKey bool
 }
 
 func NewDefaultAction(
@@ -74,6 +114,22 @@ func NewDefaultAction(
 	}
 }
 
+/*
+Sets whether the container providing the DefaultAction handles its shortcut as a key
+(see KeyHandledAction)
+*/
+func (m *DefaultAction) SetHandledAsKey(handledAsKey bool) *DefaultAction {
+	m.handledAsKey = handledAsKey
+	return m
+}
+
+/*
+Returns true if the container providing the DefaultAction handles its shortcut as a key
+*/
+func (m DefaultAction) IsHandledAsKey() bool {
+	return m.handledAsKey
+}
+
 /*
 Executes the 'execute' function, if provided
 */
@@ -112,6 +112,17 @@ func (m *ActionStack) Execute(action Action) *ActionStack {
 	return m
 }
 
+/*
+Pushes the given Action onto the executed stack without running its execute
+function (for Actions that were already executed elsewhere)
+*/
+func (m *ActionStack) Push(action Action) *ActionStack {
+	if action != nil {
+		m.executedActions = pushAction(m.executedActions, action)
+	}
+	return m
+}
+
 /*
 Pops the top Action from the executed stack, runs its undo function,
 then pushes the popped Action onto the the undone stack
@@ -0,0 +1,149 @@
+package container
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	TOGGLE_COLLAPSE_KEY = "alt+c"
+	EXPAND_ALL_KEY      = "alt+C"
+)
+
+/*
+Returns whether the Component is collapsed down to the line of its border that holds its title
+*/
+func (m Component) IsCollapsed() bool {
+	return m.collapsed
+}
+
+/*
+Sets whether the Component is collapsed down to the line of its border that holds its title
+*/
+func (m *Component) SetCollapsed(collapsed bool) *Component {
+	m.collapsed = collapsed
+	return m
+}
+
+func (m *Component) ToggleCollapsed() *Component {
+	m.collapsed = !m.collapsed
+	return m
+}
+
+/*
+Returns whether the Component can still receive focus while it's collapsed
+*/
+func (m Component) IsFocusableWhenCollapsed() bool {
+	return m.focusableWhenCollapsed
+}
+
+/*
+Sets whether the Component can still receive focus while it's collapsed
+(collapsed components are skipped by focus handlers by default)
+*/
+func (m *Component) SetFocusableWhenCollapsed(focusable bool) *Component {
+	m.focusableWhenCollapsed = focusable
+	return m
+}
+
+/*
+Renders the collapsed Component as a single line of its border with its title on it. The
+line is a column if the Component is one cell wide, otherwise it's a row
+*/
+func (m Component) renderCollapsed(style lipgloss.Style) string {
+	size := m.GetSize()
+	if size.Width < 1 || size.Height < 1 {
+		return ""
+	}
+	border := style.GetBorderStyle()
+	lineStyle := lipgloss.NewStyle().Foreground(style.GetBorderTopForeground())
+	title := []rune(m.GetTitle())
+	hasBorder := border != NO_BORDER_STYLE.GetBorderStyle()
+
+	// fills the given number of cells with the title, followed by the given filler
+	fill := func(length int, filler string) []string {
+		cells := make([]string, 0, length)
+		for i := range length {
+			if i < len(title) {
+				cells = append(cells, string(title[i]))
+			} else {
+				cells = append(cells, filler)
+			}
+		}
+		return cells
+	}
+
+	if size.Width == 1 && size.Height > 1 {
+		if !hasBorder {
+			return lineStyle.Render(strings.Join(fill(size.Height, " "), "\n"))
+		}
+		cells := append([]string{border.TopLeft}, fill(size.Height-2, border.Left)...)
+		cells = append(cells, border.BottomLeft)
+		return lineStyle.Render(strings.Join(cells[:size.Height], "\n"))
+	}
+
+	if !hasBorder || size.Width < 3 {
+		return lineStyle.Render(strings.Join(fill(size.Width, " "), ""))
+	}
+	return lineStyle.Render(border.TopLeft + strings.Join(fill(size.Width-2, border.Top), "") + border.TopRight)
+}
+
+/*
+Returns an Action that collapses the focused component of the container with the given ChangeQueue
+(or expands it, if it's already collapsed). The change is queued for the container's latest copy
+*/
+func NewToggleCollapseAction(changes *ChangeQueue) Action {
+	var target *Component
+	toggle := func(*Component) {
+		changes.Queue(func(container Container) {
+			if target == nil && container.GetFocusHandler() != nil {
+				target = GetChildContaining(container.GetComponents(), container.GetFocusHandler().GetFocusedComponent())
+			}
+			if target != nil {
+				target.ToggleCollapsed()
+			}
+		})
+	}
+	return NewDefaultAction(
+		"toggle collapse",
+		"Fold the focused pane down to its title bar, or unfold it",
+		TOGGLE_COLLAPSE_KEY,
+		nil,
+		toggle,
+		toggle,
+	)
+}
+
+/*
+Returns an Action that expands all of the collapsed components of the container with the given
+ChangeQueue (which can't be focused to be expanded individually). The change is queued for the
+container's latest copy. Undoing the Action collapses the same components again
+*/
+func NewExpandAllAction(changes *ChangeQueue) Action {
+	var expanded []*Component
+	return NewDefaultAction(
+		"expand panes",
+		"Unfold all of the collapsed panes in the focused pane's container",
+		EXPAND_ALL_KEY,
+		nil,
+		func(*Component) {
+			changes.Queue(func(container Container) {
+				expanded = nil
+				for _, component := range container.GetComponents() {
+					if component.IsCollapsed() {
+						component.SetCollapsed(false)
+						expanded = append(expanded, component)
+					}
+				}
+			})
+		},
+		func(*Component) {
+			changes.Queue(func(Container) {
+				for _, component := range expanded {
+					component.SetCollapsed(true)
+				}
+			})
+		},
+	)
+}
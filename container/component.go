@@ -2,6 +2,7 @@ package container
 
 import (
 	"math"
+	"reflect"
 	"strings"
 
 	"github.com/argotnaut/vanitea/colors"
@@ -14,6 +15,10 @@ var FOCUSED_BORDER_STYLE = lipgloss.NewStyle().BorderStyle(
 	lipgloss.RoundedBorder(),
 ).BorderForeground(lipgloss.Color(colors.FOCUSED_BORDER))
 
+var CONTAINS_FOCUS_BORDER_STYLE = lipgloss.NewStyle().BorderStyle(
+	lipgloss.RoundedBorder(),
+).BorderForeground(lipgloss.Color(colors.CONTAINS_FOCUS_BORDER))
+
 var BORDER_STYLE = lipgloss.NewStyle().BorderStyle(
 	lipgloss.RoundedBorder(),
 ).BorderForeground(lipgloss.Color(colors.UNFOCUSED_BORDER))
@@ -49,6 +54,10 @@ Manages the rendering and behavior of a bubbletea TUI component within a Contain
 type Component struct {
 	// The bubbletea model for the TUI component
 	model tea.Model
+	// An optional identifier for the component, which should be unique within its layout
+	id string
+	// Optional labels that selectors can match the component by (see Query)
+	tags []string
 	// A number the linearContainer uses to determine resizing priority
 	// (a higher priority means the linearContainer will grow it first when resizing)
 	priority int
@@ -66,12 +75,28 @@ type Component struct {
 	minimumHeight int
 	// The style of the border to render around the component
 	borderStyle lipgloss.Style
-	// The style of the border to render around the component
+	// The style of the border to render around the component when it's in focus
 	focusedBorderStyle lipgloss.Style
+	// The style of the border to render around the component when a component nested inside of it is in focus
+	containsFocusBorderStyle lipgloss.Style
 	// Whether the component can receive focus
 	focusable bool
+	// Whether the component has focus (as of the last time its container delivered focus)
+	focused bool
+	// Whether a component nested inside of the component has focus (as of the last time its container delivered focus)
+	containsFocus bool
 	// Whether the component should be skipped when rendering
 	hidden bool
+	// Whether the component is rendered as only the line of its border that holds its title
+	collapsed bool
+	// Whether the component can still receive focus while it's collapsed
+	focusableWhenCollapsed bool
+	// Whether focus is kept inside the component (and its nested components) while it's visible
+	trapsFocus bool
+	// The FocusHandler whose key bindings apply while the component's focus scope is active (optional)
+	scopeFocusHandler FocusHandler
+	// The component that had focus before focus moved into the component's scope
+	focusReturn *Component
 	// An optional title to render on the border of the component
 	title string
 	// Whether to render the component's title as part of the border
@@ -88,25 +113,52 @@ type Component struct {
 	actions []Action
 	// Will shrink to fit its content when layed-out
 	shrinkToContent bool
+	// How much space the component asks for along the major axis of the container laying it out
+	sizeHint SizeHint
+	// What the component's container looked like before the component was zoomed (nil if it isn't zoomed)
+	zoom *zoomRecord
+	// The area the component occupies within its parent container (set when the container lays it out)
+	bounds Rectangle
+	// The area inside the component's border, relative to its parent container
+	contentBounds Rectangle
+	// The position of the parent container's content area, relative to the root of the layout
+	parentOriginX int
+	parentOriginY int
+	// The point of a stacking container that the component is anchored to when layered over other components
+	stackAnchor utils.Position
+	// The vertical offset of the component from its stack anchor
+	stackOffsetY int
+	// The horizontal offset of the component from its stack anchor
+	stackOffsetX int
+	// Which lifecycle messages the component's model has been sent (see DeliverLifecycle)
+	lifecycle lifecycleState
+	// Changes whenever a property that affects the component's rendering changes (see GetRenderRevision)
+	renderRevision uint64
+	// The component's last rendering (shared by copies of the component)
+	renderCache *RenderCache
 }
 
 /*
 Instantiates a Component with the given model and default settings
 */
 func ComponentFromModel(model tea.Model) *Component {
-	return &Component{
-		model:              model,
-		priority:           1,
-		maximumWidth:       math.MaxInt,
-		maximumHeight:      math.MaxInt,
-		minimumWidth:       2,
-		minimumHeight:      2,
-		borderStyle:        BORDER_STYLE,
-		focusedBorderStyle: FOCUSED_BORDER_STYLE,
-		focusable:          true,
-		titlePosition:      TOP_LEFT,
-		shortcutPosition:   BOTTOM_RIGHT,
+	component := &Component{
+		priority:                 1,
+		maximumWidth:             math.MaxInt,
+		maximumHeight:            math.MaxInt,
+		minimumWidth:             2,
+		minimumHeight:            2,
+		borderStyle:              BORDER_STYLE,
+		focusedBorderStyle:       FOCUSED_BORDER_STYLE,
+		containsFocusBorderStyle: CONTAINS_FOCUS_BORDER_STYLE,
+		focusable:                true,
+		titlePosition:            TOP_LEFT,
+		shortcutPosition:         BOTTOM_RIGHT,
+		stackAnchor:              utils.TOP_LEFT,
+		renderCache:              &RenderCache{},
 	}
+	component.SetModel(model)
+	return component
 }
 
 /*
@@ -120,10 +172,42 @@ func (m Component) GetModel() tea.Model {
 }
 
 /*
-Sets the Component's tea.Model to the given model
+Sets the Component's tea.Model to the given model (if the model is a FocusableModel,
+its "is focused" function is set to report whether the Component has focus)
 */
 func (m *Component) SetModel(model tea.Model) *Component {
-	m.model = model
+	// models that don't report their own revisions are rendered again if they were replaced by a different model
+	if _, isRevisioner := model.(RenderRevisioner); !isRevisioner && !isSameModel(m.model, model) {
+		m.InvalidateRender()
+	}
+	m.model = wireFocusableModel(model, m)
+	return m
+}
+
+/*
+Returns true if the given models are equal values (models that can't be compared never are)
+*/
+func isSameModel(a tea.Model, b tea.Model) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	aValue, bValue := reflect.ValueOf(a), reflect.ValueOf(b)
+	return aValue.Type() == bValue.Type() && aValue.Comparable() && bValue.Comparable() && aValue.Equal(bValue)
+}
+
+/*
+Returns the Component's identifier
+*/
+func (m Component) GetID() string {
+	return m.id
+}
+
+/*
+Sets the Component's identifier, which should be unique within its layout (components
+without one are skipped when a layout is saved or restored)
+*/
+func (m *Component) SetID(id string) *Component {
+	m.id = id
 	return m
 }
 
@@ -135,7 +219,8 @@ func (m Component) GetPriority() int {
 }
 
 /*
-Sets the resizing priority for the Component
+Sets the resizing priority for the Component. When a container's space can't be split evenly
+between the components sharing it, the components with higher priorities get the extra cells first
 */
 func (m *Component) SetPriority(priority int) *Component {
 	m.priority = priority
@@ -223,6 +308,7 @@ Sets the lipgloss.Style of the Component's border when it is not in focus
 */
 func (m *Component) SetBorderStyle(style lipgloss.Style) *Component {
 	m.borderStyle = style
+	m.InvalidateRender()
 	return m
 }
 
@@ -238,14 +324,46 @@ Sets the lipgloss.Style of the Component's border when it is in focus
 */
 func (m *Component) SetFocusBorderStyle(style lipgloss.Style) *Component {
 	m.focusedBorderStyle = style
+	m.InvalidateRender()
 	return m
 }
 
+/*
+Gets the lipgloss.Style of the Component's border when a component nested inside of it is in focus
+*/
+func (m Component) GetContainsFocusBorderStyle() lipgloss.Style {
+	return m.containsFocusBorderStyle
+}
+
+/*
+Sets the lipgloss.Style of the Component's border when a component nested inside of it is in focus
+*/
+func (m *Component) SetContainsFocusBorderStyle(style lipgloss.Style) *Component {
+	m.containsFocusBorderStyle = style
+	m.InvalidateRender()
+	return m
+}
+
+/*
+Returns the lipgloss.Style of the Component's border, given whether its container renders it as
+focused: its focus border style if it is, its "contains focus" border style if a component nested
+inside of it is in focus (see ContainsFocus), and its regular border style otherwise
+*/
+func (m Component) GetCurrentBorderStyle(focused bool) lipgloss.Style {
+	switch {
+	case focused:
+		return m.GetFocusBorderStyle()
+	case m.ContainsFocus():
+		return m.GetContainsFocusBorderStyle()
+	}
+	return m.GetBorderStyle()
+}
+
 /*
 Returns whether the component is capable of receiving focus
 */
 func (m Component) IsFocusable() bool {
-	if m.IsHidden() {
+	if m.IsHidden() || (m.IsCollapsed() && !m.IsFocusableWhenCollapsed()) {
 		return false
 	}
 	return m.focusable
@@ -279,6 +397,7 @@ func (m Component) GetTitle() string {
 
 func (m *Component) SetTitle(title string) *Component {
 	m.title = title
+	m.InvalidateRender()
 	return m
 
 }
@@ -289,16 +408,18 @@ func (m Component) GetTitlePosition() int {
 
 func (m *Component) SetTitlePosition(titlePosition int) *Component {
 	m.titlePosition = titlePosition
+	m.InvalidateRender()
 	return m
 
 }
 
 func (m Component) GetShortcut() string {
-	return m.title
+	return m.shortcut
 }
 
 func (m *Component) SetShortcut(shortcut string) *Component {
 	m.shortcut = shortcut
+	m.InvalidateRender()
 	return m
 }
 
@@ -308,6 +429,7 @@ func (m Component) GetShortcutPosition() int {
 
 func (m *Component) SetShortcutPosition(shortcutPosition int) *Component {
 	m.shortcutPosition = shortcutPosition
+	m.InvalidateRender()
 	return m
 }
 
@@ -331,11 +453,13 @@ func (m Component) IsShowingTitle() bool {
 
 func (m *Component) SetShowTitle(showTitle bool) *Component {
 	m.showTitle = showTitle
+	m.InvalidateRender()
 	return m
 }
 
 func (m *Component) ToggleShowTitle() *Component {
 	m.showTitle = !m.showTitle
+	m.InvalidateRender()
 	return m
 }
 
@@ -345,11 +469,13 @@ func (m Component) IsShowingShortcut() bool {
 
 func (m *Component) SetShowShortcut(showShortcut bool) *Component {
 	m.showShortcut = showShortcut
+	m.InvalidateRender()
 	return m
 }
 
 func (m *Component) ToggleShowShortcut() *Component {
 	m.showShortcut = !m.showShortcut
+	m.InvalidateRender()
 	return m
 }
 
@@ -359,6 +485,7 @@ Sets the Component's width and height to those of the given tea.WindowSizeMsg
 func (m *Component) SetSize(size tea.WindowSizeMsg) {
 	m.height = size.Height
 	m.width = size.Width
+	m.lifecycle.laidOut = true
 }
 
 /*
@@ -371,6 +498,45 @@ func (m Component) GetSize() tea.WindowSizeMsg {
 	}
 }
 
+/*
+Returns the point of a stacking container that the Component is anchored to
+*/
+func (m Component) GetStackAnchor() utils.Position {
+	return m.stackAnchor
+}
+
+/*
+Sets the point of a stacking container that the Component is anchored to
+*/
+func (m *Component) SetStackAnchor(anchor utils.Position) *Component {
+	m.stackAnchor = anchor
+	return m
+}
+
+/*
+Returns the vertical and horizontal offsets of the Component from its stack anchor
+*/
+func (m Component) GetStackOffset() (vertical int, horizontal int) {
+	return m.stackOffsetY, m.stackOffsetX
+}
+
+/*
+Sets the vertical and horizontal offsets of the Component from its stack anchor
+*/
+func (m *Component) SetStackOffset(vertical int, horizontal int) *Component {
+	m.stackOffsetY = vertical
+	m.stackOffsetX = horizontal
+	return m
+}
+
+/*
+Returns the area the Component occupies within its parent container, as of
+the last time the container laid it out
+*/
+func (m Component) GetBounds() Rectangle {
+	return m.bounds
+}
+
 func (m Component) GetClampedHeight(inputHeight int) int {
 	return utils.ClampInt(
 		inputHeight,
@@ -401,6 +567,23 @@ model will use (when being layed-out)
 */
 func (m *Component) SetShrinkToContent(input bool) *Component {
 	m.shrinkToContent = input
+	m.InvalidateRender()
+	return m
+}
+
+/*
+Returns how much space the Component asks for along the major axis of its container
+*/
+func (m Component) GetSizeHint() SizeHint {
+	return m.sizeHint
+}
+
+/*
+Sets how much space the Component asks for along the major axis of its container
+(e.g. FixedSize(12), PercentSize(30) or WeightedSize(2))
+*/
+func (m *Component) SetSizeHint(hint SizeHint) *Component {
+	m.sizeHint = hint
 	return m
 }
 
@@ -417,6 +600,10 @@ func (m *Component) Update(message tea.Msg) tea.Cmd {
 	}
 	newModel, outputCmd := m.GetModel().Update(message)
 	m.SetModel(newModel)
+	// a model that doesn't report its own revisions may have changed its View in any way when it was sent a message
+	if _, isRevisioner := newModel.(RenderRevisioner); !isRevisioner {
+		m.InvalidateRender()
+	}
 	return outputCmd
 }
 
@@ -457,11 +644,19 @@ Renders the component's model according to it's current properties
 and with the correct focus styling
 */
 func (m Component) render(focused bool) string {
-	var currentStyle lipgloss.Style
-	if focused {
-		currentStyle = m.GetFocusBorderStyle()
-	} else {
-		currentStyle = m.GetBorderStyle()
+	// the rendering is cached until something it depends on changes
+	revision := NewRenderHasher().
+		AddUint64(m.GetRenderRevision()).
+		AddSize(m.GetSize()).
+		AddBool(focused, m.IsFocused(), m.ContainsFocus(), m.IsCollapsed(), m.IsZoomed()).
+		Sum()
+	return m.renderCache.Get(revision, func() string { return m.renderUncached(focused) })
+}
+
+func (m Component) renderUncached(focused bool) string {
+	currentStyle := m.GetCurrentBorderStyle(focused)
+	if m.IsCollapsed() {
+		return m.renderCollapsed(currentStyle)
 	}
 	renderSize := m.GetSize()
 	renderSize.Height = max(0, renderSize.Height-currentStyle.GetVerticalFrameSize())
@@ -481,6 +676,9 @@ func (m Component) render(focused bool) string {
 	// Find out what text is meant to be rendered at each corner, based on the title and shortcut positions
 	getTextForCorner := func(corner int) string {
 		if m.titlePosition == corner {
+			if m.IsZoomed() {
+				return strings.TrimSpace(m.title + " " + ZOOMED_INDICATOR)
+			}
 			return m.title
 		}
 		if m.shortcutPosition == corner {
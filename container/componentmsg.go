@@ -0,0 +1,145 @@
+package container
+
+import tea "github.com/charmbracelet/bubbletea"
+
+/*
+Implemented by the messages that change which components a container holds at runtime. Each one
+is handled by the container whose Component has the message's container ID (containers pass it on
+to the one nested inside of them), or by the outermost container that receives it if the container
+ID is empty. The container lays its components out again afterwards, and new components' models
+are initialized and mounted (see MountMsg) once they've been laid out
+*/
+type ComponentsMsg interface {
+	// Returns the ID of the Component whose container should handle the message
+	GetContainerID() string
+	// Returns the message with the given container ID
+	withContainerID(string) ComponentsMsg
+}
+
+/*
+Inserts Component into the container at Index (or appends it, if Index is negative or past the end)
+*/
+type InsertComponentMsg struct {
+	ContainerID string
+	Component   *Component
+	Index       int
+}
+
+/*
+Removes the component with the ID ComponentID from the container, sending it an UnmountMsg. If it
+had focus, focus moves to another component (or back to where it was before, if focus was trapped
+inside of the removed component)
+*/
+type RemoveComponentMsg struct {
+	ContainerID string
+	ComponentID string
+}
+
+/*
+Puts Component in the place of the component with the ID ComponentID (which is removed, as with
+a RemoveComponentMsg)
+*/
+type ReplaceComponentMsg struct {
+	ContainerID string
+	ComponentID string
+	Component   *Component
+}
+
+/*
+Moves the component with the ID ComponentID to Index in the container's list of components
+*/
+type MoveComponentMsg struct {
+	ContainerID string
+	ComponentID string
+	Index       int
+}
+
+/*
+Reports an error from carrying out a message (like a ComponentsMsg that the container it targets
+doesn't support), so that the application can show or log it
+*/
+type ErrorMsg struct {
+	Err error
+}
+
+/*
+Returns a tea.Cmd that reports the given error (see ErrorMsg), or nil if there's no error
+*/
+func ReportError(err error) tea.Cmd {
+	if err == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return ErrorMsg{Err: err}
+	}
+}
+
+func (msg InsertComponentMsg) GetContainerID() string  { return msg.ContainerID }
+func (msg RemoveComponentMsg) GetContainerID() string  { return msg.ContainerID }
+func (msg ReplaceComponentMsg) GetContainerID() string { return msg.ContainerID }
+func (msg MoveComponentMsg) GetContainerID() string    { return msg.ContainerID }
+
+func (msg InsertComponentMsg) withContainerID(id string) ComponentsMsg {
+	msg.ContainerID = id
+	return msg
+}
+
+func (msg RemoveComponentMsg) withContainerID(id string) ComponentsMsg {
+	msg.ContainerID = id
+	return msg
+}
+
+func (msg ReplaceComponentMsg) withContainerID(id string) ComponentsMsg {
+	msg.ContainerID = id
+	return msg
+}
+
+func (msg MoveComponentMsg) withContainerID(id string) ComponentsMsg {
+	msg.ContainerID = id
+	return msg
+}
+
+/*
+Returns the component with the given ID among the given components and the components
+nested inside of them, or nil if there isn't one
+*/
+func GetComponentByID(components []*Component, id string) (output *Component) {
+	if id == "" {
+		return nil
+	}
+	WalkComponents(components, func(component *Component) {
+		if output == nil && component.GetID() == id {
+			output = component
+		}
+	})
+	return output
+}
+
+/*
+Passes the given message on to the component (among the given components) that either is the
+container it targets or contains that container, returning the tea.Cmd from its update. Does
+nothing if none of the given components are or contain a container with the message's container ID.
+Also returns the given FocusHandler, which (if its focused component was removed) gives focus to
+the component that the container the message was passed on to moved focus to
+*/
+func ForwardComponentsMsg(components []*Component, handler FocusHandler, msg ComponentsMsg) (tea.Cmd, FocusHandler) {
+	target := GetComponentByID(components, msg.GetContainerID())
+	if target == nil {
+		return nil, handler
+	}
+	if _, isCont := target.GetModel().(Container); !isCont {
+		return nil, handler
+	}
+	child := GetChildContaining(components, target)
+	var cmd tea.Cmd
+	if child == target {
+		// the container handles messages whose container ID is empty itself
+		cmd = child.Update(msg.withContainerID(""))
+	} else {
+		cmd = child.Update(msg)
+	}
+	if focused := handler.GetFocusedComponent(); focused != nil && GetChildContaining(components, focused) == nil {
+		handler = handler.SetFocusedComponent(child.GetModel().(Container).GetFocusHandler().GetFocusedComponent())
+	}
+	return cmd, handler
+}
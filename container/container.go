@@ -1,7 +1,35 @@
 package container
 
+import "slices"
+
 type Container interface {
 	GetComponents() []*Component
 	GetVisibleComponents() []*Component
 	GetFocusHandler() FocusHandler
 }
+
+/*
+Calls the given function on each of the given components and on every component nested
+inside of them. Each component is visited before the components nested inside of it
+*/
+func WalkComponents(components []*Component, visit func(*Component)) {
+	for _, component := range components {
+		if component == nil {
+			continue
+		}
+		visit(component)
+		if cont, isCont := component.GetModel().(Container); isCont {
+			WalkComponents(cont.GetComponents(), visit)
+		}
+	}
+}
+
+/*
+Returns a copy of the given list (of a container's components, or of its placements of them) in which
+the elements from start up to end are replaced with the given elements, as slices.Replace would. Containers
+change these lists through it rather than in place, since copies of a container (like the one its
+FocusHandler's component delegate was made from) share them and would otherwise be changed underneath
+*/
+func Splice[T any](list []T, start int, end int, elements ...T) []T {
+	return slices.Replace(slices.Clone(list), start, end, elements...)
+}
@@ -0,0 +1,104 @@
+package container
+
+import (
+	"github.com/argotnaut/vanitea/utils"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+/*
+Implemented by models that can report how much space their content takes up without being
+rendered. Containers use it to lay out components that shrink to their content (see
+SetShrinkToContent), and only fall back to rendering the models of components whose models
+don't implement it at each size they try. The minimum size of a model's content also narrows
+down the sizes its Component can be laid out at (see GetLayoutBounds), which is how containers
+report the minimum sizes of the components inside of them. The maximum size only does for
+Components that shrink to their content and for containers, whose maximum sizes are made up of
+the ones their components were given or shrink to
+*/
+type ContentSizer interface {
+	// Returns the size of the model's content when it's laid out within the given constraint
+	GetContentSize(constraint tea.WindowSizeMsg) ContentSize
+}
+
+/*
+The sizes a model's content can take up (see ContentSizer)
+*/
+type ContentSize struct {
+	// The size the content takes up within the constraint (no larger than the constraint)
+	Preferred tea.WindowSizeMsg
+	// The smallest size the content can be shown at (regardless of the constraint)
+	Minimum tea.WindowSizeMsg
+	// The largest size the content can make use of, regardless of the constraint (math.MaxInt if it can use any amount of space)
+	Maximum tea.WindowSizeMsg
+}
+
+/*
+Returns the ContentSize with the given frame (like a border) added to each of its sizes
+*/
+func (s ContentSize) WithFrame(width int, height int) ContentSize {
+	grow := func(size tea.WindowSizeMsg) tea.WindowSizeMsg {
+		return tea.WindowSizeMsg{Width: addFrame(size.Width, width), Height: addFrame(size.Height, height)}
+	}
+	return ContentSize{Preferred: grow(s.Preferred), Minimum: grow(s.Minimum), Maximum: grow(s.Maximum)}
+}
+
+// Adds a frame to a size, leaving unbounded sizes unbounded
+func addFrame(size int, frame int) int {
+	return utils.SaturatingSum(size, frame)
+}
+
+/*
+Returns the size the Component's model's content takes up within the given constraint (the size
+the Component would be set to), including the frame of the given style (the border the Component
+is rendered with). Returns false if the model isn't a ContentSizer
+*/
+func (m Component) GetContentSize(constraint tea.WindowSizeMsg, style lipgloss.Style) (ContentSize, bool) {
+	sizer, isSizer := m.GetModel().(ContentSizer)
+	if !isSizer {
+		return ContentSize{}, false
+	}
+	frameWidth, frameHeight := style.GetHorizontalFrameSize(), style.GetVerticalFrameSize()
+	return sizer.GetContentSize(tea.WindowSizeMsg{
+		Width:  max(0, constraint.Width-frameWidth),
+		Height: max(0, constraint.Height-frameHeight),
+	}).WithFrame(frameWidth, frameHeight), true
+}
+
+/*
+Returns the smallest and largest sizes the Component can be laid out at when it's rendered with the
+given style's frame: its own minimum and maximum sizes, narrowed down by the minimum size of its model's
+content (including the frame) if its model is a ContentSizer, and by the maximum size of the content if
+the Component also shrinks to its content (see SetShrinkToContent) or its model is a Container (whose
+components' maximum sizes are the ones they were given or shrink to, so they're passed up through nested
+containers). Other Components fill the space they're given past the size of their content. A collapsed
+Component only renders its border, so its model's content doesn't matter
+*/
+func (m Component) GetLayoutBounds(style lipgloss.Style) (minimum tea.WindowSizeMsg, maximum tea.WindowSizeMsg) {
+	minimum = tea.WindowSizeMsg{Width: m.GetMinimumWidth(), Height: m.GetMinimumHeight()}
+	maximum = tea.WindowSizeMsg{Width: m.GetMaximumWidth(), Height: m.GetMaximumHeight()}
+	if m.IsHidden() || m.IsCollapsed() {
+		return minimum, maximum
+	}
+	if contentSize, isSizer := m.GetContentSize(m.GetSize(), style); isSizer {
+		minimum.Width = max(minimum.Width, contentSize.Minimum.Width)
+		minimum.Height = max(minimum.Height, contentSize.Minimum.Height)
+		if _, isContainer := m.GetModel().(Container); m.ShrinkToContent() || isContainer {
+			maximum.Width = min(maximum.Width, contentSize.Maximum.Width)
+			maximum.Height = min(maximum.Height, contentSize.Maximum.Height)
+		}
+	}
+	maximum.Width, maximum.Height = max(minimum.Width, maximum.Width), max(minimum.Height, maximum.Height)
+	return minimum, maximum
+}
+
+/*
+Returns the given size clamped between the Component's layout bounds (see GetLayoutBounds)
+*/
+func (m Component) GetLayoutClampedSize(size tea.WindowSizeMsg, style lipgloss.Style) tea.WindowSizeMsg {
+	minimum, maximum := m.GetLayoutBounds(style)
+	return tea.WindowSizeMsg{
+		Width:  utils.ClampInt(size.Width, minimum.Width, maximum.Width),
+		Height: utils.ClampInt(size.Height, minimum.Height, maximum.Height),
+	}
+}
@@ -0,0 +1,136 @@
+package container
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/*
+A Container whose FocusHandler can be replaced. The containers in this module implement it (with
+pointer receivers), and share the logic for keeping their focus valid, removing and replacing
+their components, and routing messages to their components through it
+*/
+type EditableContainer interface {
+	Container
+	SetFocusHandler(handler FocusHandler)
+}
+
+/*
+Moves the container's focus to the first of the given candidates that can receive it (see
+GetAllFocusableComponents) if the focused component can't (because it was hidden or collapsed,
+or because a focus scope was opened or closed). Focus that was in a focus scope that closed
+returns to where it was before the scope was entered. Focus that was given to a component
+outside of the container (by a parent container) is left alone
+*/
+func EnsureValidFocus(container EditableContainer, candidates []*Component) {
+	handler := container.GetFocusHandler()
+	if handler == nil {
+		return
+	}
+	focused := handler.GetFocusedComponent()
+	if focused != nil && GetChildContaining(container.GetComponents(), focused) == nil {
+		return
+	}
+	focusable := GetAllFocusableComponents(candidates)
+	if len(focusable) < 1 || slices.Contains(focusable, focused) {
+		return
+	}
+	// the container's components may include ones it doesn't show, whose focus scopes aren't open
+	returnTo := getFocusReturn(container.GetComponents(), focused, GetActiveFocusTrap(container.GetVisibleComponents()))
+	if slices.Contains(focusable, returnTo) {
+		container.SetFocusHandler(handler.SetFocusedComponent(returnTo))
+		return
+	}
+	container.SetFocusHandler(handler.SetFocusedComponent(focusable[0]))
+}
+
+/*
+Removes the given component from the container with the given remove function (which takes it out
+of the container's list of components), returning the tea.Cmd from unmounting it (see Component.Unmount).
+A zoomed component is unzoomed first. If the focused component was removed, focus returns to where it
+was before it was trapped inside of the removed component (the container should make sure its focus is
+valid afterward, see EnsureValidFocus)
+*/
+func RemoveComponent(container EditableContainer, component *Component, remove func()) tea.Cmd {
+	if GetZoomedComponent(container.GetComponents()) == component {
+		Unzoom(container.GetComponents())
+	}
+	focused := container.GetFocusHandler().GetFocusedComponent()
+	lostFocus := GetChildContaining([]*Component{component}, focused) != nil
+	returnTo := GetRemovedFocusReturn(component, focused)
+	remove()
+	// the focus handler's delegate holds a copy of the old list of components, so it needs to be refreshed
+	container.SetFocusHandler(container.GetFocusHandler())
+	if lostFocus {
+		container.SetFocusHandler(container.GetFocusHandler().SetFocusedComponent(returnTo))
+	}
+	return component.Unmount()
+}
+
+/*
+Puts the given replacement in the place of the given component with the given replace function (which
+adds the replacement to the container and removes the component as by RemoveComponent), returning the
+tea.Cmd it returns. If the replaced component had focus, the replacement gets it (if it can receive it)
+*/
+func ReplaceComponent(container EditableContainer, component *Component, replacement *Component, replace func() tea.Cmd) tea.Cmd {
+	hadFocus := GetChildContaining([]*Component{component}, container.GetFocusHandler().GetFocusedComponent()) != nil
+	cmd := replace()
+	if focusable := GetAllFocusableComponents([]*Component{replacement}); hadFocus && len(focusable) > 0 {
+		container.SetFocusHandler(container.GetFocusHandler().SetFocusedComponent(focusable[0]))
+	}
+	return cmd
+}
+
+/*
+Updates the given container with the given message using the given update function, then sends the
+components the lifecycle messages (see DeliverLifecycle) and delivers focus (see DeliverFocus) in case
+the message laid them out, showed or hid them, or changed which one has focus. FocusMsgs, BlurMsgs and
+lifecycle messages are meant for the container's own component, not for the components inside of it,
+so the container ignores them
+*/
+func UpdateContainer(container tea.Model, msg tea.Msg, update func(tea.Msg) (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case FocusMsg, BlurMsg:
+		return container, nil
+	}
+	if IsLifecycleMsg(msg) {
+		return container, nil
+	}
+	model, cmd := update(msg)
+	updated := model.(Container)
+	return model, tea.Batch(
+		cmd,
+		deliverLifecycle(updated.GetComponents(), updated.GetVisibleComponents()),
+		DeliverFocus(updated.GetComponents(), updated.GetFocusHandler().GetFocusedComponent()),
+	)
+}
+
+/*
+Routes the given tea.MouseMsg to the container's visible component under the cursor (with its
+coordinates translated into that component's space) and, if the message is a click, moves the
+container's focus to the most deeply nested focusable component under the cursor. Clicks are passed
+to the given onClick function (if there is one) first, which can handle them itself by returning true.
+Clicks outside of the active focus scope (see GetActiveFocusTrap) are dropped, so they can't move
+focus to (or otherwise act on) the components around or underneath it
+*/
+func RouteMouseMsg(container EditableContainer, msg tea.MouseMsg, onClick func(target *Component) (tea.Cmd, bool)) tea.Cmd {
+	target := GetComponentAt(container.GetVisibleComponents(), msg.X, msg.Y)
+	if target == nil {
+		return nil
+	}
+	if IsFocusingMouseMsg(msg) {
+		if !IsClickInFocusScope(container.GetVisibleComponents(), msg.X, msg.Y) {
+			return nil
+		}
+		if onClick != nil {
+			if cmd, handled := onClick(target); handled {
+				return cmd
+			}
+		}
+		if focusTarget := GetFocusableComponentAt(container.GetVisibleComponents(), msg.X, msg.Y); focusTarget != nil {
+			container.SetFocusHandler(container.GetFocusHandler().SetFocusedComponent(focusTarget))
+		}
+	}
+	return target.Update(target.ToLocalMouseMsg(msg))
+}
@@ -33,15 +33,28 @@ type FocusHandler interface {
 
 /*
 Returns a slice of the components (including their child components, if they have any)
-that are capable of receiving focus
+that are capable of receiving focus. While a focus scope is active (see GetActiveFocusTrap),
+only the components inside of it can receive focus
 */
-func GetAllFocusableComponents(components []*Component) (output []*Component) {
+func GetAllFocusableComponents(components []*Component) []*Component {
+	if trap := GetActiveFocusTrap(components); trap != nil {
+		components = []*Component{trap}
+	}
+	return getAllFocusableComponents(components)
+}
+
+func getAllFocusableComponents(components []*Component) (output []*Component) {
 	for _, component := range components {
 		if component.IsFocusable() {
 			output = append(output, component)
 		}
+		// the components nested in hidden or collapsed components aren't rendered, so they can't receive focus
+		if component.IsHidden() || component.IsCollapsed() {
+			continue
+		}
+		// neither can the ones their container doesn't show (like the inactive tabs of a tab container)
 		if cont, isCont := component.GetModel().(Container); isCont {
-			output = append(output, GetAllFocusableComponents(cont.GetComponents())...)
+			output = append(output, getAllFocusableComponents(cont.GetVisibleComponents())...)
 		}
 	}
 	return
@@ -0,0 +1,77 @@
+package container
+
+import tea "github.com/charmbracelet/bubbletea"
+
+/*
+Sent to a Component's model when the Component receives focus
+*/
+type FocusMsg struct{}
+
+/*
+Sent to a Component's model when the Component loses focus
+*/
+type BlurMsg struct{}
+
+/*
+Implemented by container models that keep track of their own focused component,
+so that the container they're nested in can tell them which component has focus
+*/
+type FocusSyncable interface {
+	tea.Model
+	// Returns the model with the given component as its focused component
+	SyncFocus(focused *Component) tea.Model
+}
+
+/*
+Returns whether the Component has focus, as of the last time its container delivered focus
+*/
+func (m Component) IsFocused() bool {
+	return m.focused
+}
+
+/*
+Records whether the Component has focus. If that changed, a FocusMsg or BlurMsg is sent
+to the Component's model and the tea.Cmd it returns is passed on
+*/
+func (m *Component) SetFocused(focused bool) tea.Cmd {
+	if m.focused == focused || m.GetModel() == nil {
+		return nil
+	}
+	m.focused = focused
+	if focused {
+		return m.Update(FocusMsg{})
+	}
+	return m.Update(BlurMsg{})
+}
+
+/*
+Gives the focused component (and only that component) focus among the given components and
+all of the components nested inside of them, sending a FocusMsg or BlurMsg to the model of
+each component whose focus changed. Nested containers that keep track of their own focused
+component are told which one has focus, so that they deliver the same focus when they're updated.
+Focus scopes that focus moved into remember the component to return focus to (see GetFocusReturn),
+and the focused component's ancestors record that they contain focus (see Component.ContainsFocus)
+*/
+func DeliverFocus(components []*Component, focused *Component) tea.Cmd {
+	recordFocusReturns(components, focused)
+	recordContainsFocus(components, focused)
+	var cmds []tea.Cmd
+	WalkComponents(components, func(component *Component) {
+		if syncable, isSyncable := component.GetModel().(FocusSyncable); isSyncable {
+			component.SetModel(syncable.SyncFocus(focused))
+		}
+		cmds = append(cmds, component.SetFocused(component == focused))
+	})
+	return tea.Batch(cmds...)
+}
+
+/*
+If the given model is a FocusableModel, returns it with its "is focused" function
+reporting whether the given Component has focus
+*/
+func wireFocusableModel(model tea.Model, component *Component) tea.Model {
+	if focusable, isFocusable := model.(FocusableModel); isFocusable {
+		return focusable.SetIsFocusedFunction(func(FocusableModel) bool { return component.IsFocused() })
+	}
+	return model
+}
@@ -0,0 +1,112 @@
+package container
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/*
+Implemented by FocusHandlers that move focus around in a cycle, so that they can tell whether moving
+focus with a key would wrap around (from the last component back to the first, or the other way
+around). A nested container's FocusHandler leaves the keys that would wrap to the containers around
+it (see HandleNestedFocusKey), so that focus can leave the nested container
+*/
+type FocusWrapper interface {
+	// Returns whether handling the given key would wrap focus around
+	WouldWrap(key string) bool
+}
+
+/*
+Returns whether handling the given key with the given FocusHandler would wrap focus around (see FocusWrapper)
+*/
+func wouldWrap(handler FocusHandler, key string) bool {
+	wrapper, isWrapper := handler.(FocusWrapper)
+	return isWrapper && wrapper.WouldWrap(key)
+}
+
+/*
+Returns the path from the component among the given components that contains the focused
+component down to the focused component itself, through the components of every Container
+in between (or nil if the focused component isn't among them). The last component of the
+path is the focused leaf, and the ones before it are its ancestors
+*/
+func GetFocusPath(components []*Component, focused *Component) []*Component {
+	child := GetChildContaining(components, focused)
+	if child == nil {
+		return nil
+	}
+	if child == focused {
+		return []*Component{child}
+	}
+	return append([]*Component{child}, GetFocusPath(child.GetModel().(Container).GetComponents(), focused)...)
+}
+
+/*
+Returns whether a component nested inside of the Component has focus, as of the last time its
+container delivered focus (the Component itself having focus doesn't count, see IsFocused)
+*/
+func (m Component) ContainsFocus() bool {
+	return m.containsFocus
+}
+
+/*
+Records, for each of the given components and the components nested inside of them, whether
+a component nested inside of it has focus
+*/
+func recordContainsFocus(components []*Component, focused *Component) {
+	ancestors := GetFocusPath(components, focused)
+	if len(ancestors) > 0 {
+		ancestors = ancestors[:len(ancestors)-1]
+	}
+	WalkComponents(components, func(component *Component) {
+		component.containsFocus = slices.Contains(ancestors, component)
+	})
+}
+
+/*
+Moves focus according to the given key, handling it at the innermost scope that can use it: the
+FocusHandlers of the containers along the focus path (see GetFocusPath) are tried from the innermost
+one outward, and the first one that the key moves focus with (without wrapping around, see FocusWrapper)
+handles it. If none of them do, the key is handled by the given FocusHandler (the one of the outermost
+container) and the key bindings of the active focus scope (see HandleScopedFocusKey). Containers nested
+outside of the active focus scope are skipped, so focus can't leave it. Returns the resulting FocusHandler
+and whether the key was a focus key
+*/
+func HandleNestedFocusKey(components []*Component, handler FocusHandler, key string) (FocusHandler, bool) {
+	focused := handler.GetFocusedComponent()
+	path := GetFocusPath(components, focused)
+	// the containers outside of (and including) the active focus scope are handled by HandleScopedFocusKey
+	if trapIdx := slices.Index(path, GetActiveFocusTrap(components)); trapIdx >= 0 {
+		path = path[trapIdx+1:]
+	}
+	for idx := len(path) - 2; idx >= 0; idx-- {
+		cont, isCont := path[idx].GetModel().(Container)
+		if !isCont || cont.GetFocusHandler() == nil {
+			continue
+		}
+		nested := cont.GetFocusHandler().SetFocusedComponent(focused)
+		if !nested.IsFocusKey(key) || wouldWrap(nested, key) {
+			continue
+		}
+		target := nested.HandleFocusKey(key).GetFocusedComponent()
+		// the nested FocusHandler may not focus the leaves themselves (like one that only moves between its own components)
+		if target != nil && target != focused && slices.Contains(GetAllFocusableComponents(cont.GetVisibleComponents()), target) {
+			return handler.SetFocusedComponent(target), true
+		}
+	}
+	return HandleScopedFocusKey(components, handler, key)
+}
+
+/*
+Passes the given key on to the focused component if it's one of the given components or nested inside of
+one of them, returning the tea.Cmd from its update. A focused component outside of them (like the component
+containing their container, which a parent container gave focus) isn't sent the key, since it would pass
+the key back to their container
+*/
+func ForwardKey(components []*Component, handler FocusHandler, msg tea.KeyMsg) tea.Cmd {
+	if focused := handler.GetFocusedComponent(); GetChildContaining(components, focused) != nil {
+		return focused.Update(msg)
+	}
+	return nil
+}
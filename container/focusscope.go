@@ -0,0 +1,162 @@
+package container
+
+import "slices"
+
+/*
+Returns whether the component traps focus (see SetTrapsFocus)
+*/
+func (m Component) TrapsFocus() bool {
+	return m.trapsFocus
+}
+
+/*
+Sets whether the component traps focus. While a component that traps focus is visible,
+it's a focus scope: only it and the components nested inside of it can receive focus
+(see GetAllFocusableComponents), so focus keys can't move focus to the components around
+or underneath it (like the panes underneath a dialog). When the component is hidden, the
+scope closes and focus returns to the component that had it before the scope was entered
+*/
+func (m *Component) SetTrapsFocus(trapsFocus bool) *Component {
+	m.trapsFocus = trapsFocus
+	return m
+}
+
+/*
+Returns the FocusHandler whose key bindings apply while the component's focus scope is active
+*/
+func (m Component) GetScopeFocusHandler() FocusHandler {
+	return m.scopeFocusHandler
+}
+
+/*
+Sets the FocusHandler whose key bindings apply (instead of those of the container's FocusHandler)
+while the component's focus scope is active. If it's nil, the container's FocusHandler is used
+*/
+func (m *Component) SetScopeFocusHandler(handler FocusHandler) *Component {
+	m.scopeFocusHandler = handler
+	return m
+}
+
+/*
+Returns the active focus scope among the given components and the components nested inside of
+them: the innermost of the visible components that trap focus, favoring the last one (the top-most
+one, when they're stacked) if there's more than one. Returns nil if none of them trap focus
+*/
+func GetActiveFocusTrap(components []*Component) (output *Component) {
+	for _, component := range components {
+		// the components nested in hidden or collapsed components (or that their container doesn't show) aren't rendered, so their scopes aren't open
+		if component == nil || component.IsHidden() {
+			continue
+		}
+		if component.TrapsFocus() {
+			output = component
+		}
+		if component.IsCollapsed() {
+			continue
+		}
+		if cont, isCont := component.GetModel().(Container); isCont {
+			if trap := GetActiveFocusTrap(cont.GetVisibleComponents()); trap != nil {
+				output = trap
+			}
+		}
+	}
+	return output
+}
+
+/*
+Returns whether a click at the given coordinates lands inside of the active focus scope among the
+given visible components (see GetActiveFocusTrap): whether the most deeply nested focusable component
+under the cursor is one that the scope lets receive focus. Every click lands inside of the scope
+when there isn't an active one
+*/
+func IsClickInFocusScope(visible []*Component, x int, y int) bool {
+	trap := GetActiveFocusTrap(visible)
+	if trap == nil {
+		return true
+	}
+	focusTarget := GetFocusableComponentAt(visible, x, y)
+	return focusTarget != nil && slices.Contains(GetAllFocusableComponents([]*Component{trap}), focusTarget)
+}
+
+/*
+Returns the component that had focus before focus moved into the (since closed) focus
+scope that the given component is in, or nil if the component isn't in a closed scope
+*/
+func GetFocusReturn(components []*Component, focused *Component) *Component {
+	return getFocusReturn(components, focused, GetActiveFocusTrap(components))
+}
+
+/*
+Returns the component that had focus before focus moved into the focus scope that the given
+component is in, where the scope is the given removed component or one nested inside of it
+(or nil if the component isn't in one of those scopes)
+*/
+func GetRemovedFocusReturn(removed *Component, focused *Component) *Component {
+	return getFocusReturn([]*Component{removed}, focused, nil)
+}
+
+func getFocusReturn(components []*Component, focused *Component, active *Component) (output *Component) {
+	if focused == nil {
+		return nil
+	}
+	WalkComponents(components, func(component *Component) {
+		if component.TrapsFocus() && component != active && component.focusReturn != nil &&
+			GetChildContaining([]*Component{component}, focused) != nil {
+			output = component.focusReturn
+		}
+	})
+	return output
+}
+
+/*
+Records, for each focus scope among the given components (and the components nested inside of
+them), the component to return focus to when the scope closes: the one that had focus before
+focus moved from outside of the scope to the given focused component inside of it
+*/
+func recordFocusReturns(components []*Component, focused *Component) {
+	var previous *Component
+	WalkComponents(components, func(component *Component) {
+		if component.IsFocused() {
+			previous = component
+		}
+	})
+	if previous == focused {
+		return
+	}
+	WalkComponents(components, func(component *Component) {
+		if !component.TrapsFocus() {
+			return
+		}
+		scope := []*Component{component}
+		switch {
+		case GetChildContaining(scope, focused) == nil:
+			component.focusReturn = nil
+		case previous != nil && GetChildContaining(scope, previous) == nil:
+			component.focusReturn = previous
+		}
+	})
+}
+
+/*
+Moves focus according to the given key and the key bindings of the active focus scope among the
+given components (see SetScopeFocusHandler), or those of the given FocusHandler if the scope doesn't
+have its own. Returns the resulting FocusHandler and whether the key was one of the bindings
+*/
+func HandleScopedFocusKey(components []*Component, handler FocusHandler, key string) (FocusHandler, bool) {
+	trap := GetActiveFocusTrap(components)
+	if trap == nil || trap.GetScopeFocusHandler() == nil {
+		if !handler.IsFocusKey(key) {
+			return handler, false
+		}
+		return handler.HandleFocusKey(key), true
+	}
+	scope := trap.GetScopeFocusHandler().
+		SetComponentDelegate(func() []*Component { return []*Component{trap} }).
+		SetFocusedComponent(handler.GetFocusedComponent())
+	if !scope.IsFocusKey(key) {
+		return handler, false
+	}
+	scope = scope.HandleFocusKey(key)
+	trap.SetScopeFocusHandler(scope)
+	return handler.SetFocusedComponent(scope.GetFocusedComponent()), true
+}
@@ -0,0 +1,124 @@
+package container
+
+import "github.com/charmbracelet/lipgloss"
+
+/*
+Describes an axis-aligned area of the screen (in cells)
+*/
+type Rectangle struct {
+	// The column of the rectangle's left edge
+	X int
+	// The row of the rectangle's top edge
+	Y int
+	// The number of columns the rectangle spans
+	Width int
+	// The number of rows the rectangle spans
+	Height int
+}
+
+/*
+Returns whether the given cell lies within the Rectangle
+*/
+func (r Rectangle) Contains(x int, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+/*
+Returns whether the Rectangle covers no cells
+*/
+func (r Rectangle) IsEmpty() bool {
+	return r.Width < 1 || r.Height < 1
+}
+
+/*
+Returns the Rectangle moved by the given horizontal and vertical displacement
+*/
+func (r Rectangle) Translate(dx int, dy int) Rectangle {
+	r.X += dx
+	r.Y += dy
+	return r
+}
+
+/*
+Returns the Rectangle shrunk by the margins, borders and padding of the given style
+*/
+func (r Rectangle) Inset(style lipgloss.Style) Rectangle {
+	left := style.GetMarginLeft() + style.GetBorderLeftSize() + style.GetPaddingLeft()
+	top := style.GetMarginTop() + style.GetBorderTopSize() + style.GetPaddingTop()
+	return Rectangle{
+		X:      r.X + left,
+		Y:      r.Y + top,
+		Width:  max(0, r.Width-style.GetHorizontalFrameSize()),
+		Height: max(0, r.Height-style.GetVerticalFrameSize()),
+	}
+}
+
+/*
+Records the area the Component occupies within its parent container, along with
+the area inside of the given border style, and updates the absolute geometry of
+any components nested inside of it
+*/
+func (m *Component) SetLayout(bounds Rectangle, style lipgloss.Style) *Component {
+	m.bounds = bounds
+	m.contentBounds = bounds.Inset(style)
+	m.updateNestedOrigins()
+	return m
+}
+
+/*
+Sets the position of the parent container's content area (relative to the root of
+the layout) and updates the absolute geometry of any components nested inside of
+the Component
+*/
+func (m *Component) SetParentOrigin(x int, y int) *Component {
+	m.parentOriginX = x
+	m.parentOriginY = y
+	m.updateNestedOrigins()
+	return m
+}
+
+/*
+Passes the position of the Component's content area down to the components
+of its model, if the model has any
+*/
+func (m *Component) updateNestedOrigins() {
+	parent, isParent := m.GetModel().(interface{ GetComponents() []*Component })
+	if !isParent {
+		return
+	}
+	content := m.GetContentBounds()
+	for _, component := range parent.GetComponents() {
+		if component != nil && component != m {
+			component.SetParentOrigin(content.X, content.Y)
+		}
+	}
+}
+
+/*
+Returns the position of the Component's top-left corner, relative to the root of the layout
+*/
+func (m Component) GetOrigin() (x int, y int) {
+	frame := m.GetFrame()
+	return frame.X, frame.Y
+}
+
+/*
+Returns the area the Component occupies (including its border), relative to the root of the layout
+*/
+func (m Component) GetFrame() Rectangle {
+	return m.bounds.Translate(m.parentOriginX, m.parentOriginY)
+}
+
+/*
+Returns the area inside of the Component's border, relative to the root of the layout
+*/
+func (m Component) GetContentBounds() Rectangle {
+	return m.contentBounds.Translate(m.parentOriginX, m.parentOriginY)
+}
+
+/*
+Returns the area inside of the Component's border, relative to its parent container
+*/
+func (m Component) GetLocalContentBounds() Rectangle {
+	return m.contentBounds
+}
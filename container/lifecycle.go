@@ -0,0 +1,171 @@
+package container
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/*
+Sent to a Component's model the first time the Component is laid out while it's visible,
+with the size the Component was given
+*/
+type MountMsg struct {
+	Size tea.WindowSizeMsg
+}
+
+/*
+Sent to a Component's model when the Component is removed from its container (see Component.Unmount)
+*/
+type UnmountMsg struct{}
+
+/*
+Sent to a mounted Component's model when the Component stops being hidden
+*/
+type ShowMsg struct{}
+
+/*
+Sent to a mounted Component's model when the Component is hidden
+*/
+type HideMsg struct{}
+
+/*
+Sent to a mounted, visible Component's model when the size its container gives it changes,
+with the size it had when its model was last told (by a MountMsg or ResizeMsg) and its new size
+*/
+type ResizeMsg struct {
+	Old tea.WindowSizeMsg
+	New tea.WindowSizeMsg
+}
+
+/*
+Returns whether the given message is one of the lifecycle messages sent to the model of a
+single Component (containers shouldn't pass these on to the components inside of them)
+*/
+func IsLifecycleMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case MountMsg, UnmountMsg, ShowMsg, HideMsg, ResizeMsg:
+		return true
+	}
+	return false
+}
+
+/*
+Which lifecycle messages a Component's model has been sent, and what it was told in them
+*/
+type lifecycleState struct {
+	// Whether the model's Init function has been called
+	initialized bool
+	// Whether a container has laid the component out (given it a size) since it was last unmounted
+	laidOut bool
+	// Whether the model has been sent a MountMsg (and not an UnmountMsg since)
+	mounted bool
+	// Whether the model was last told the component is hidden
+	hidden bool
+	// The size the model was last told the component has
+	size tea.WindowSizeMsg
+}
+
+/*
+Calls the Init function of the Component's model, unless it has already been called
+*/
+func (m *Component) Init() tea.Cmd {
+	if m.lifecycle.initialized || m.GetModel() == nil {
+		return nil
+	}
+	m.lifecycle.initialized = true
+	return m.GetModel().Init()
+}
+
+/*
+Returns whether the Component's model has been sent a MountMsg (and not an UnmountMsg since)
+*/
+func (m Component) IsMounted() bool {
+	return m.lifecycle.mounted
+}
+
+/*
+Sends an UnmountMsg to the models of the Component and of every mounted component nested
+inside of it (blurring the one that has focus first), which should be done when the Component is removed from its container. If
+the Component is added to a container again, it's sent a new MountMsg once it's laid out
+*/
+func (m *Component) Unmount() tea.Cmd {
+	var cmds []tea.Cmd
+	WalkComponents([]*Component{m}, func(component *Component) {
+		// the removed components aren't reached when focus is delivered anymore, so they're blurred here
+		cmds = append(cmds, component.SetFocused(false))
+		wasMounted := component.lifecycle.mounted
+		component.lifecycle.laidOut = false
+		component.lifecycle.mounted = false
+		if wasMounted {
+			cmds = append(cmds, component.Update(UnmountMsg{}))
+		}
+	})
+	return tea.Batch(cmds...)
+}
+
+/*
+Sends the model of the Component whichever lifecycle messages describe what happened to the
+Component since they were last sent: a MountMsg (after calling its Init function, if that hasn't
+been done yet) if it has been laid out for the first time, a ShowMsg or HideMsg if it was shown or
+hidden, and a ResizeMsg if it was given a new size. shown is whether the Component's container shows
+it (see Container.GetVisibleComponents), since it can be left out without being hidden itself
+*/
+func (m *Component) deliverLifecycle(shown bool) tea.Cmd {
+	if m.GetModel() == nil {
+		return nil
+	}
+	state := &m.lifecycle
+	hidden := m.IsHidden() || !shown
+	if !state.mounted {
+		if !state.laidOut || hidden {
+			return nil
+		}
+		state.mounted, state.hidden, state.size = true, false, m.GetSize()
+		return tea.Batch(m.Init(), m.Update(MountMsg{Size: state.size}))
+	}
+	var cmds []tea.Cmd
+	if hidden != state.hidden {
+		state.hidden = hidden
+		if state.hidden {
+			cmds = append(cmds, m.Update(HideMsg{}))
+		} else {
+			cmds = append(cmds, m.Update(ShowMsg{}))
+		}
+	}
+	// hidden components are laid out with no size at all, which the model doesn't need to hear about
+	if size := m.GetSize(); !state.hidden && size != state.size {
+		old := state.size
+		state.size = size
+		cmds = append(cmds, m.Update(ResizeMsg{Old: old, New: size}))
+	}
+	return tea.Batch(cmds...)
+}
+
+/*
+Sends the lifecycle messages (see MountMsg, ShowMsg, HideMsg and ResizeMsg) that describe what
+happened since they were last sent to the models of the given components and of all the components
+nested inside of them. Containers do this after each update, so components added after the program
+started are initialized and mounted as soon as they're laid out
+*/
+func DeliverLifecycle(components []*Component) tea.Cmd {
+	return deliverLifecycle(components, components)
+}
+
+/*
+The same as DeliverLifecycle(), but only the given components that are among the given visible
+ones are shown (the nested containers' components are shown if the containers show them)
+*/
+func deliverLifecycle(components []*Component, visible []*Component) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, component := range components {
+		if component == nil {
+			continue
+		}
+		cmds = append(cmds, component.deliverLifecycle(slices.Contains(visible, component)))
+		if cont, isCont := component.GetModel().(Container); isCont {
+			cmds = append(cmds, deliverLifecycle(cont.GetComponents(), cont.GetVisibleComponents()))
+		}
+	}
+	return tea.Batch(cmds...)
+}
@@ -178,3 +178,17 @@ func (lfh linearFocusHandler) HandleFocusKey(key string) FocusHandler {
 	}
 	return lfh
 }
+
+/*
+Returns whether the given key would send focus from the last component back to the first
+(or from the first to the last)
+*/
+func (lfh linearFocusHandler) WouldWrap(key string) bool {
+	components := lfh.componentDelegate()
+	idx := slices.Index(components, lfh.GetFocusedComponent())
+	if idx < 0 {
+		return false
+	}
+	return (slices.Contains(lfh.keyMap.FocusForward, key) && idx == len(components)-1) ||
+		(slices.Contains(lfh.keyMap.FocusBackward, key) && idx == 0)
+}
@@ -0,0 +1,67 @@
+package container
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/*
+Returns the last visible component (in rendering order) whose bounds
+contain the given point, or nil if there isn't one
+*/
+func GetComponentAt(components []*Component, x int, y int) (output *Component) {
+	for _, component := range components {
+		if component == nil || component.IsHidden() {
+			continue
+		}
+		if component.GetBounds().Contains(x, y) {
+			output = component
+		}
+	}
+	return
+}
+
+/*
+Returns the most deeply nested focusable component under the given point,
+descending into the models of any components that are Containers
+*/
+func GetFocusableComponentAt(components []*Component, x int, y int) *Component {
+	target := GetComponentAt(components, x, y)
+	if target == nil {
+		return nil
+	}
+	if cont, isCont := target.GetModel().(Container); isCont {
+		localX, localY := target.ToLocalPoint(x, y)
+		if nested := GetFocusableComponentAt(cont.GetVisibleComponents(), localX, localY); nested != nil {
+			return nested
+		}
+	}
+	if target.IsFocusable() {
+		return target
+	}
+	return nil
+}
+
+/*
+Returns whether the given mouse message should move focus to the component under the cursor
+*/
+func IsFocusingMouseMsg(msg tea.MouseMsg) bool {
+	return msg.Action == tea.MouseActionPress && !tea.MouseEvent(msg).IsWheel()
+}
+
+/*
+Converts a point in the coordinate space of the Component's parent into the
+coordinate space of the Component's model (inside of its border)
+*/
+func (m Component) ToLocalPoint(x int, y int) (int, int) {
+	content := m.GetLocalContentBounds()
+	return x - content.X, y - content.Y
+}
+
+/*
+Returns a copy of the given tea.MouseMsg with its coordinates translated into the
+coordinate space of the Component's model (inside of its border)
+*/
+func (m Component) ToLocalMouseMsg(msg tea.MouseMsg) tea.MouseMsg {
+	msg.X, msg.Y = m.ToLocalPoint(msg.X, msg.Y)
+	return msg
+}
@@ -0,0 +1,255 @@
+package container
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/argotnaut/vanitea/colors"
+	"github.com/argotnaut/vanitea/utils"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	FOCUS_PREVIOUS_KEY = "alt+o"
+	// How soon another FOCUS_PREVIOUS_KEY press has to follow the last one to keep cycling back through the history
+	// (terminals don't report when a modifier key is released, so repeated presses stand in for holding it down)
+	MRU_CYCLE_TIMEOUT = time.Second
+)
+
+var MRU_OVERLAY_STYLE = lipgloss.NewStyle().
+	BorderStyle(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color(colors.FOCUSED_BORDER)).
+	Padding(0, 1)
+
+var MRU_SELECTED_STYLE = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(colors.SELECTLIST_SELECTED))
+
+var MRU_DESELECTED_STYLE = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.SELECTLIST_DESELECTED))
+
+/*
+Implemented by FocusHandlers that have something to render over their container (like
+the history shown while cycling through recently focused components)
+*/
+type FocusOverlay interface {
+	// Returns the rendering to place over the center of the container, or "" if there isn't one
+	RenderOverlay() string
+	// Returns a number that changes whenever RenderOverlay would return something different (without rendering it)
+	GetOverlayRevision() uint64
+}
+
+/*
+Sent once a FocusHandler's overlay may have expired, so that the container is rendered without it
+*/
+type FocusOverlayExpiredMsg struct{}
+
+/*
+Returns a tea.Cmd that sends a FocusOverlayExpiredMsg once the given FocusHandler's
+overlay expires, or nil if it isn't showing one
+*/
+func GetFocusOverlayCmd(handler FocusHandler) tea.Cmd {
+	overlay, hasOverlay := handler.(FocusOverlay)
+	if !hasOverlay || overlay.RenderOverlay() == "" {
+		return nil
+	}
+	return tea.Tick(MRU_CYCLE_TIMEOUT, func(time.Time) tea.Msg { return FocusOverlayExpiredMsg{} })
+}
+
+/*
+Renders the given FocusHandler's overlay (if it has one) over the center of the given view
+*/
+func PlaceFocusOverlay(view string, handler FocusHandler) string {
+	overlay, hasOverlay := handler.(FocusOverlay)
+	if !hasOverlay || view == "" {
+		return view
+	}
+	if rendering := overlay.RenderOverlay(); rendering != "" {
+		return utils.PlaceStacked(view, rendering, utils.CENTER, 0, 0)
+	}
+	return view
+}
+
+/*
+Wraps another FocusHandler, keeping a most-recently-used history of the focused components.
+Pressing FOCUS_PREVIOUS_KEY jumps back to the previously focused component, and pressing it
+again within MRU_CYCLE_TIMEOUT goes further back through the history (while an overlay of
+the components' titles is shown). Every other key is passed on to the wrapped FocusHandler
+*/
+type mruFocusHandler struct {
+	// The FocusHandler that handles every key other than the history keys
+	handler FocusHandler
+	// The keys that move focus back through the history
+	previousKeys []string
+	// The focused components, from the most recently focused to the least
+	history []*Component
+	// The function used to get the container's components
+	componentDelegate func() []*Component
+	// How far back through the history focus has been moved by the current cycle (0 when not cycling)
+	cycleIndex int
+	// When FOCUS_PREVIOUS_KEY was last pressed
+	lastCycle time.Time
+}
+
+/*
+Instantiates an mruFocusHandler that wraps the given FocusHandler, using FOCUS_PREVIOUS_KEY
+*/
+func NewDefaultMRUFocusHandler(handler FocusHandler) mruFocusHandler {
+	return NewMRUFocusHandler(handler, []string{FOCUS_PREVIOUS_KEY})
+}
+
+/*
+Instantiates an mruFocusHandler that wraps the given FocusHandler and moves focus
+back through the history when one of the given keys is pressed
+*/
+func NewMRUFocusHandler(handler FocusHandler, previousKeys []string) mruFocusHandler {
+	return mruFocusHandler{handler: handler, previousKeys: previousKeys}.record()
+}
+
+/*
+Returns the FocusHandler wrapped by the mruFocusHandler
+*/
+func (mfh mruFocusHandler) GetWrappedHandler() FocusHandler {
+	return mfh.handler
+}
+
+/*
+Returns the focus history (from the most recently focused component to the least),
+leaving out the components that were removed, hidden or otherwise can't be focused anymore
+*/
+func (mfh mruFocusHandler) GetHistory() (output []*Component) {
+	if mfh.componentDelegate == nil {
+		return slices.Clone(mfh.history)
+	}
+	focusable := GetAllFocusableComponents(mfh.componentDelegate())
+	for _, component := range mfh.history {
+		if slices.Contains(focusable, component) {
+			output = append(output, component)
+		}
+	}
+	return output
+}
+
+/*
+Returns whether FOCUS_PREVIOUS_KEY was pressed recently enough for another press to keep cycling
+*/
+func (mfh mruFocusHandler) IsCycling() bool {
+	return mfh.cycleIndex > 0 && time.Since(mfh.lastCycle) < MRU_CYCLE_TIMEOUT
+}
+
+/*
+Moves the focused component to the front of the history (unless focus is being cycled
+through the history, in which case the history stays in the same order until the cycle ends)
+*/
+func (mfh mruFocusHandler) record() mruFocusHandler {
+	if mfh.IsCycling() {
+		return mfh
+	}
+	mfh.cycleIndex = 0
+	focused := mfh.handler.GetFocusedComponent()
+	history := mfh.GetHistory()
+	if focused == nil || (len(history) > 0 && history[0] == focused) {
+		mfh.history = history
+		return mfh
+	}
+	history = slices.DeleteFunc(history, func(component *Component) bool { return component == focused })
+	mfh.history = append([]*Component{focused}, history...)
+	return mfh
+}
+
+/*
+Sets the component delegate function of the mruFocusHandler and of the FocusHandler it wraps
+*/
+func (mfh mruFocusHandler) SetComponentDelegate(componentDelegate func() []*Component) FocusHandler {
+	mfh.componentDelegate = componentDelegate
+	mfh.handler = mfh.handler.SetComponentDelegate(componentDelegate)
+	return mfh.record()
+}
+
+/*
+Returns true if the given string moves focus back through the history or is a
+key combination that the wrapped FocusHandler uses
+*/
+func (mfh mruFocusHandler) IsFocusKey(key string) bool {
+	return slices.Contains(mfh.previousKeys, key) || mfh.handler.IsFocusKey(key)
+}
+
+/*
+Moves focus back through the history, or passes the key on to the wrapped FocusHandler
+*/
+func (mfh mruFocusHandler) HandleFocusKey(key string) FocusHandler {
+	if !slices.Contains(mfh.previousKeys, key) {
+		mfh.cycleIndex = 0
+		mfh.handler = mfh.handler.HandleFocusKey(key)
+		return mfh.record()
+	}
+	// a press after the last cycle timed out starts a new cycle from the (re-ordered) history
+	mfh = mfh.record()
+	history := mfh.GetHistory()
+	if len(history) < 2 {
+		return mfh
+	}
+	mfh.history = history
+	mfh.cycleIndex = mfh.cycleIndex%(len(history)-1) + 1
+	mfh.lastCycle = time.Now()
+	mfh.handler = mfh.handler.SetFocusedComponent(history[mfh.cycleIndex])
+	return mfh
+}
+
+/*
+Returns whether the wrapped FocusHandler would wrap focus around with the given key (see FocusWrapper).
+Moving back through the history never counts as wrapping around
+*/
+func (mfh mruFocusHandler) WouldWrap(key string) bool {
+	return !slices.Contains(mfh.previousKeys, key) && wouldWrap(mfh.handler, key)
+}
+
+func (mfh mruFocusHandler) GetFocusedComponent() *Component {
+	return mfh.handler.GetFocusedComponent()
+}
+
+func (mfh mruFocusHandler) SetFocusedComponent(component *Component) FocusHandler {
+	if component == mfh.handler.GetFocusedComponent() {
+		return mfh
+	}
+	mfh.cycleIndex = 0
+	mfh.handler = mfh.handler.SetFocusedComponent(component)
+	return mfh.record()
+}
+
+/*
+Returns a number that changes whenever the overlay would be rendered differently: when cycling
+through the history starts or stops, or when the highlighted component or the history changes
+*/
+func (mfh mruFocusHandler) GetOverlayRevision() uint64 {
+	if !mfh.IsCycling() {
+		return 0
+	}
+	hasher := NewRenderHasher().AddInt(mfh.cycleIndex)
+	for _, component := range mfh.history {
+		hasher.AddString(component.GetTitle())
+	}
+	return hasher.Sum()
+}
+
+/*
+Renders the titles of the components in the focus history (highlighting the one
+that's focused) while focus is being cycled through the history
+*/
+func (mfh mruFocusHandler) RenderOverlay() string {
+	if !mfh.IsCycling() {
+		return ""
+	}
+	var lines []string
+	for idx, component := range mfh.history {
+		title := strings.TrimSpace(component.GetTitle())
+		if title == "" {
+			title = "(untitled)"
+		}
+		if idx == mfh.cycleIndex {
+			lines = append(lines, MRU_SELECTED_STYLE.Render("> "+title))
+		} else {
+			lines = append(lines, MRU_DESELECTED_STYLE.Render("  "+title))
+		}
+	}
+	return MRU_OVERLAY_STYLE.Render(strings.Join(lines, "\n"))
+}
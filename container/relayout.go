@@ -0,0 +1,76 @@
+package container
+
+import tea "github.com/charmbracelet/bubbletea"
+
+/*
+Implemented by containers that lay out their components themselves
+*/
+type LayoutContainer interface {
+	Container
+	ResizeComponents(containerSize tea.WindowSizeMsg) tea.Cmd
+}
+
+/*
+Sent after an Action was executed or undone, so that containers make the changes their Actions
+queued up (see ChangeQueue) and lay their components out again. Actions change things that are
+shared by every copy of a container (like its components' size hints, or which of its components
+are hidden, collapsed or zoomed), but only the copy of the container that bubbletea keeps can lay
+the components out and pass the tea.Cmds from resizing them on, so whatever runs an Action sends a
+RelayoutMsg afterward (see Relayout)
+*/
+type RelayoutMsg struct{}
+
+/*
+A tea.Cmd that sends a RelayoutMsg
+*/
+func Relayout() tea.Msg {
+	return RelayoutMsg{}
+}
+
+/*
+Lays the container's components out again in the given area (the one it was last laid out in),
+so that changes to its components (like their size hints) take effect without waiting for a
+tea.WindowSizeMsg. Does nothing if the container hasn't been laid out yet
+*/
+func RelayoutContainer(container LayoutContainer, size tea.WindowSizeMsg) tea.Cmd {
+	if size.Width < 1 && size.Height < 1 {
+		return nil
+	}
+	return container.ResizeComponents(size)
+}
+
+/*
+The changes that a container's Actions made to it, waiting for the container to make them (shared
+by all copies of the container, like its RenderCache). Actions hold on to the copy of the container
+they were made from, which is out of date by the time they're executed or undone (and isn't the copy
+bubbletea keeps), so they queue their changes here and the container makes them the next time it's
+updated (see ApplyChanges)
+*/
+type ChangeQueue struct {
+	changes []func(Container)
+}
+
+/*
+Queues the given change, which is made to the latest copy of the container (passed to it as a pointer)
+*/
+func (q *ChangeQueue) Queue(change func(container Container)) {
+	if q != nil {
+		q.changes = append(q.changes, change)
+	}
+}
+
+/*
+Makes the changes the container's Actions queued up (see ChangeQueue) to the given copy of the
+container (a pointer to the copy being updated), in the order they were queued. Containers do this
+before handling each message, so that the copy bubbletea keeps is the one Actions act on
+*/
+func ApplyChanges(queue *ChangeQueue, container Container) {
+	if queue == nil {
+		return
+	}
+	changes := queue.changes
+	queue.changes = nil
+	for _, change := range changes {
+		change(container)
+	}
+}
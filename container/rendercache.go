@@ -0,0 +1,231 @@
+package container
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"io"
+	"math"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/*
+Implemented by models that keep track of when their View would return something different, so
+that their Components only render them again when it would. The models of Components whose models
+don't implement it are rendered again whenever a message is routed to them through Component.Update
+(or they're replaced by a different model)
+*/
+type RenderRevisioner interface {
+	// Returns a number that changes whenever the model's View would return something different
+	GetRenderRevision() uint64
+}
+
+// The last revision handed out by NewRenderRevision
+var lastRenderRevision atomic.Uint64
+
+/*
+Returns a revision number that hasn't been returned before, for RenderRevisioners to
+take on whenever their View changes (so that no two changes share a revision)
+*/
+func NewRenderRevision() uint64 {
+	return lastRenderRevision.Add(1)
+}
+
+/*
+The last rendering of something, along with the revision it was rendered at
+*/
+type RenderCache struct {
+	revision uint64
+	output   string
+	valid    bool
+}
+
+/*
+Returns the cached rendering if it was rendered at the given revision, otherwise renders it again
+with the given function (and caches that). A nil RenderCache always renders
+*/
+func (c *RenderCache) Get(revision uint64, render func() string) string {
+	if c == nil {
+		return render()
+	}
+	if !c.valid || c.revision != revision {
+		c.output, c.revision, c.valid = render(), revision, true
+	}
+	return c.output
+}
+
+/*
+Discards the cached rendering
+*/
+func (c *RenderCache) Invalidate() {
+	if c != nil {
+		c.valid = false
+	}
+}
+
+/*
+Combines the values that a rendering depends on into a single revision number. Containers compute
+one every time they're rendered, so values are written into the hash directly rather than formatted
+*/
+type RenderHasher struct {
+	hash hash.Hash64
+	// Holds each number while it's written into the hash
+	buffer [8]byte
+}
+
+func NewRenderHasher() *RenderHasher {
+	return &RenderHasher{hash: fnv.New64a()}
+}
+
+/*
+Adds the given numbers to the revision
+*/
+func (h *RenderHasher) AddUint64(values ...uint64) *RenderHasher {
+	for _, value := range values {
+		binary.LittleEndian.PutUint64(h.buffer[:], value)
+		h.hash.Write(h.buffer[:])
+	}
+	return h
+}
+
+/*
+Adds the given numbers to the revision
+*/
+func (h *RenderHasher) AddInt(values ...int) *RenderHasher {
+	for _, value := range values {
+		h.AddUint64(uint64(value))
+	}
+	return h
+}
+
+/*
+Adds the given numbers to the revision
+*/
+func (h *RenderHasher) AddFloat(values ...float64) *RenderHasher {
+	for _, value := range values {
+		h.AddUint64(math.Float64bits(value))
+	}
+	return h
+}
+
+/*
+Adds the given flags to the revision
+*/
+func (h *RenderHasher) AddBool(values ...bool) *RenderHasher {
+	for _, value := range values {
+		if value {
+			h.AddUint64(1)
+		} else {
+			h.AddUint64(0)
+		}
+	}
+	return h
+}
+
+/*
+Adds the given strings to the revision (along with their lengths, so that
+adding "ab" and "c" differs from adding "a" and "bc")
+*/
+func (h *RenderHasher) AddString(values ...string) *RenderHasher {
+	for _, value := range values {
+		h.AddInt(len(value))
+		io.WriteString(h.hash, value)
+	}
+	return h
+}
+
+/*
+Adds the given size to the revision
+*/
+func (h *RenderHasher) AddSize(size tea.WindowSizeMsg) *RenderHasher {
+	return h.AddInt(size.Width, size.Height)
+}
+
+/*
+Adds the given rectangle to the revision
+*/
+func (h *RenderHasher) AddRectangle(rectangle Rectangle) *RenderHasher {
+	return h.AddInt(rectangle.X, rectangle.Y, rectangle.Width, rectangle.Height)
+}
+
+/*
+Adds everything about the given component that affects how a container renders it (given whether
+the container renders it as focused) to the revision
+*/
+func (h *RenderHasher) AddComponent(component *Component, focused bool) *RenderHasher {
+	offsetY, offsetX := component.GetStackOffset()
+	anchor := component.GetStackAnchor()
+	return h.AddUint64(component.GetRenderRevision()).
+		AddRectangle(component.GetBounds()).
+		AddSize(component.GetSize()).
+		AddBool(
+			focused,
+			component.IsFocused(),
+			component.ContainsFocus(),
+			component.IsHidden(),
+			component.IsCollapsed(),
+			component.IsZoomed(),
+		).
+		AddFloat(anchor.X, anchor.Y).
+		AddInt(offsetY, offsetX)
+}
+
+/*
+Adds the overlay of the given FocusHandler (if it has one) to the revision
+*/
+func (h *RenderHasher) AddFocusHandler(handler FocusHandler) *RenderHasher {
+	if overlay, hasOverlay := handler.(FocusOverlay); hasOverlay {
+		h.AddUint64(overlay.GetOverlayRevision())
+	}
+	return h
+}
+
+func (h *RenderHasher) Sum() uint64 {
+	return h.hash.Sum64()
+}
+
+/*
+Returns a number that changes whenever the Component's rendering (at a given size and focus)
+would change: when one of its properties that affect its rendering was set, or when its model
+changed (as reported by the model, if it's a RenderRevisioner). The components nested in models
+that are Containers but not RenderRevisioners are accounted for too, since they can change
+(e.g. gain focus) without a message being routed to the model
+*/
+func (m Component) GetRenderRevision() uint64 {
+	switch model := m.GetModel().(type) {
+	case RenderRevisioner:
+		return NewRenderHasher().AddUint64(m.renderRevision, model.GetRenderRevision()).Sum()
+	case Container:
+		hasher := NewRenderHasher().AddUint64(m.renderRevision)
+		for _, component := range model.GetComponents() {
+			if component == nil {
+				continue
+			}
+			hasher.AddComponent(component, component.IsFocused())
+		}
+		return hasher.Sum()
+	}
+	return m.renderRevision
+}
+
+/*
+Makes the Component render its model again the next time it's rendered. This is only needed when
+its model's View changes without a message being routed to it (e.g. because the model reads
+state that's shared with other models)
+*/
+func (m *Component) InvalidateRender() *Component {
+	m.renderRevision = NewRenderRevision()
+	return m
+}
+
+/*
+Makes the Component (usually a copy of one) render its model without going through the cached
+rendering it shares with its other copies, so that rendering it in a state it isn't really in
+(like at a size it's only being tried at) doesn't replace the rendering of its actual state
+*/
+func (m *Component) DetachRenderCache() *Component {
+	m.renderCache = nil
+	return m
+}
@@ -0,0 +1,347 @@
+package container
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/*
+Returns the Component's tags
+*/
+func (m Component) GetTags() []string {
+	return m.tags
+}
+
+/*
+Sets the Component's tags, which selectors can match it by (see Query)
+*/
+func (m *Component) SetTags(tags ...string) *Component {
+	m.tags = tags
+	return m
+}
+
+/*
+Adds the given tag to the Component's tags (unless it already has it)
+*/
+func (m *Component) AddTag(tag string) *Component {
+	if !m.HasTag(tag) {
+		m.tags = append(slices.Clone(m.tags), tag)
+	}
+	return m
+}
+
+/*
+Removes the given tag from the Component's tags
+*/
+func (m *Component) RemoveTag(tag string) *Component {
+	m.tags = slices.DeleteFunc(slices.Clone(m.tags), func(t string) bool { return t == tag })
+	return m
+}
+
+func (m Component) HasTag(tag string) bool {
+	return slices.Contains(m.tags, tag)
+}
+
+/*
+Returns whether the given string can be used as a name in a selector (as an ID or a tag): it
+has to be made up of letters, digits, hyphens and underscores
+*/
+func IsSelectorName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, char := range name {
+		if !isSelectorNameChar(char) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSelectorNameChar(char rune) bool {
+	return char == '-' || char == '_' ||
+		(char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
+}
+
+const (
+	// The component has to be nested (at any depth) inside of the component the previous compound selector matched
+	DESCENDANT_COMBINATOR = iota
+	// The component has to be one of the components of the previous compound selector's container
+	CHILD_COMBINATOR
+)
+
+/*
+A part of a selector that a single component has to match: "*", "name" (which matches the
+component's ID or one of its tags), "#id", ".tag" or a combination of them like "name#id.tag"
+*/
+type compoundSelector struct {
+	// How the component relates to the component matched by the previous compound selector
+	combinator int
+	// The names that have to be the component's ID or one of its tags
+	names []string
+	// The ID the component has to have (or "" for any ID)
+	id string
+	// The tags the component has to have
+	tags []string
+}
+
+func (cs compoundSelector) matches(component *Component) bool {
+	if cs.id != "" && component.GetID() != cs.id {
+		return false
+	}
+	for _, name := range cs.names {
+		if component.GetID() != name && !component.HasTag(name) {
+			return false
+		}
+	}
+	for _, tag := range cs.tags {
+		if !component.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+A parsed selector, which matches components by their IDs and tags and by the containers
+they're nested in (see ParseSelector)
+*/
+type Selector struct {
+	// The comma-separated alternatives, each of which is a chain of compound selectors
+	alternatives [][]compoundSelector
+	// The selector it was parsed from
+	source string
+}
+
+/*
+Parses a selector, which is made up of one or more comma-separated alternatives. Each
+alternative is a chain of compound selectors, each of which a component has to match:
+
+	"*"         any component
+	"name"      a component whose ID or one of whose tags is "name"
+	"#id"       the component whose ID is "id"
+	".tag"      a component with the tag "tag"
+	"a b"       a component matching b that's nested (at any depth) inside of a component matching a
+	"a > b"     a component matching b that's one of the components of a component matching a
+
+Compound selectors can be combined, e.g. "panel#editor.dirty" or "#main > .pane"
+*/
+func ParseSelector(source string) (Selector, error) {
+	fail := func(format string, args ...any) (Selector, error) {
+		return Selector{}, fmt.Errorf("invalid selector %q: %s", source, fmt.Sprintf(format, args...))
+	}
+	output := Selector{source: source}
+	for _, alternative := range strings.Split(source, ",") {
+		var chain []compoundSelector
+		combinator := DESCENDANT_COMBINATOR
+		combinatorPending := false
+		for _, token := range strings.Fields(strings.ReplaceAll(alternative, ">", " > ")) {
+			if token == ">" {
+				if len(chain) == 0 || combinatorPending {
+					return fail("\">\" has to be between two compound selectors")
+				}
+				combinator, combinatorPending = CHILD_COMBINATOR, true
+				continue
+			}
+			compound, err := parseCompoundSelector(token)
+			if err != nil {
+				return fail("%s", err)
+			}
+			compound.combinator = combinator
+			chain = append(chain, compound)
+			combinator, combinatorPending = DESCENDANT_COMBINATOR, false
+		}
+		if combinatorPending {
+			return fail("\">\" has to be between two compound selectors")
+		}
+		if len(chain) == 0 {
+			return fail("empty selector")
+		}
+		output.alternatives = append(output.alternatives, chain)
+	}
+	return output, nil
+}
+
+/*
+Parses a single compound selector (like "name#id.tag" or "*")
+*/
+func parseCompoundSelector(token string) (output compoundSelector, err error) {
+	// "*" matches any component, so it only matters when it's on its own
+	for rest := strings.TrimPrefix(token, "*"); rest != ""; {
+		prefix := rest[0]
+		if prefix == '#' || prefix == '.' {
+			rest = rest[1:]
+		}
+		end := strings.IndexFunc(rest, func(char rune) bool { return !isSelectorNameChar(char) })
+		if end < 0 {
+			end = len(rest)
+		}
+		name := rest[:end]
+		if name == "" {
+			return output, fmt.Errorf("expected a name in %q", token)
+		}
+		rest = rest[end:]
+		switch prefix {
+		case '#':
+			if output.id != "" && output.id != name {
+				return output, fmt.Errorf("%q has more than one ID", token)
+			}
+			output.id = name
+		case '.':
+			output.tags = append(output.tags, name)
+		default:
+			if len(output.names)+len(output.tags) > 0 || output.id != "" {
+				return output, fmt.Errorf("the name in %q has to come first", token)
+			}
+			output.names = append(output.names, name)
+		}
+	}
+	return output, nil
+}
+
+/*
+Returns the selector that the Selector was parsed from
+*/
+func (s Selector) String() string {
+	return s.source
+}
+
+/*
+Returns whether the given component matches the Selector, given the components it's
+nested inside of (from the outermost to the one whose container holds it directly)
+*/
+func (s Selector) Matches(component *Component, ancestors []*Component) bool {
+	for _, chain := range s.alternatives {
+		if matchChain(chain, component, ancestors) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Returns whether the component matches the last compound selector of the chain, and its
+ancestors match the rest of the chain (as the combinators between them require)
+*/
+func matchChain(chain []compoundSelector, component *Component, ancestors []*Component) bool {
+	last := chain[len(chain)-1]
+	if !last.matches(component) {
+		return false
+	}
+	rest := chain[:len(chain)-1]
+	if len(rest) == 0 {
+		return true
+	}
+	if last.combinator == CHILD_COMBINATOR {
+		if len(ancestors) == 0 {
+			return false
+		}
+		return matchChain(rest, ancestors[len(ancestors)-1], ancestors[:len(ancestors)-1])
+	}
+	for idx := len(ancestors) - 1; idx >= 0; idx-- {
+		if matchChain(rest, ancestors[idx], ancestors[:idx]) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Returns the components (among the given components and the components nested inside of
+them) that match the Selector, in tree order (each container before its components)
+*/
+func (s Selector) Select(components []*Component) (output []*Component) {
+	var walk func(components []*Component, ancestors []*Component)
+	walk = func(components []*Component, ancestors []*Component) {
+		for _, component := range components {
+			if component == nil {
+				continue
+			}
+			if s.Matches(component, ancestors) {
+				output = append(output, component)
+			}
+			if cont, isCont := component.GetModel().(Container); isCont {
+				walk(cont.GetComponents(), append(slices.Clone(ancestors), component))
+			}
+		}
+	}
+	walk(components, nil)
+	return output
+}
+
+/*
+Returns the components (among the given components and the components nested inside of them)
+that match the given selector (see ParseSelector), in tree order
+*/
+func Query(components []*Component, selector string) ([]*Component, error) {
+	parsed, err := ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Select(components), nil
+}
+
+/*
+Returns the first component (in tree order) that matches the given selector, or nil if none of them do
+*/
+func QueryOne(components []*Component, selector string) (*Component, error) {
+	matches, err := Query(components, selector)
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+	return matches[0], nil
+}
+
+/*
+Sends the given message to the models of the components that match the given selector, returning
+the tea.Cmds from their updates. The components nested inside of the matching components aren't
+sent the message unless they match too, although a matching container may pass it on to them
+(as it would any other message it receives)
+*/
+func SendToSelected(components []*Component, selector string, msg tea.Msg) (tea.Cmd, error) {
+	matches, err := Query(components, selector)
+	if err != nil {
+		return nil, err
+	}
+	var cmds []tea.Cmd
+	for _, component := range matches {
+		cmds = append(cmds, component.Update(msg))
+	}
+	return tea.Batch(cmds...), nil
+}
+
+/*
+Asks the outermost container that receives it to send Msg to the models of the components that
+match Selector (see SendToSelected). Nothing is sent if Selector isn't valid, and the error is
+reported instead (see ErrorMsg)
+*/
+type SelectedMsg struct {
+	Selector string
+	Msg      tea.Msg
+}
+
+/*
+Sends the SelectedMsg's message to the components (among the given components and the components
+nested inside of them) that match its selector, returning the tea.Cmds from their updates, or a
+tea.Cmd reporting the error if the selector isn't valid (see ErrorMsg). Containers handle the
+SelectedMsgs they receive with it, without passing them on: the components are updated in place,
+so the outermost container reaches all of them
+*/
+func HandleSelectedMsg(components []*Component, msg SelectedMsg) tea.Cmd {
+	cmd, err := SendToSelected(components, msg.Selector, msg.Msg)
+	if err != nil {
+		return ReportError(err)
+	}
+	return cmd
+}
+
+/*
+Returns a tea.Cmd that sends the given message to the components that match the given selector
+(see SelectedMsg)
+*/
+func SendToSelector(selector string, msg tea.Msg) tea.Cmd {
+	return func() tea.Msg { return SelectedMsg{Selector: selector, Msg: msg} }
+}
@@ -0,0 +1,162 @@
+package container
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type testContainer struct {
+	components []*Component
+}
+
+func (m testContainer) Init() tea.Cmd                       { return nil }
+func (m testContainer) Update(tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (m testContainer) View() string                        { return "" }
+func (m testContainer) GetComponents() []*Component         { return m.components }
+func (m testContainer) GetVisibleComponents() []*Component  { return m.components }
+func (m testContainer) GetFocusHandler() FocusHandler       { return nil }
+
+// Records the messages it receives
+type testModel struct {
+	received *[]tea.Msg
+}
+
+func (m testModel) Init() tea.Cmd { return nil }
+func (m testModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	*m.received = append(*m.received, msg)
+	return m, nil
+}
+func (m testModel) View() string { return "" }
+
+func TestParseSelector(t *testing.T) {
+	cases := []struct {
+		name    string
+		source  string
+		want    [][]compoundSelector
+		wantErr bool
+	}{
+		{name: "any", source: "*", want: [][]compoundSelector{{{}}}},
+		{name: "name", source: "editor", want: [][]compoundSelector{{{names: []string{"editor"}}}}},
+		{name: "id", source: "#editor", want: [][]compoundSelector{{{id: "editor"}}}},
+		{name: "tag", source: ".pane", want: [][]compoundSelector{{{tags: []string{"pane"}}}}},
+		{
+			name:   "compound",
+			source: "panel#editor.dirty.open",
+			want:   [][]compoundSelector{{{names: []string{"panel"}, id: "editor", tags: []string{"dirty", "open"}}}},
+		},
+		{
+			name:   "descendant",
+			source: "#main .pane",
+			want:   [][]compoundSelector{{{id: "main"}, {combinator: DESCENDANT_COMBINATOR, tags: []string{"pane"}}}},
+		},
+		{
+			name:   "child",
+			source: "#main > .pane",
+			want:   [][]compoundSelector{{{id: "main"}, {combinator: CHILD_COMBINATOR, tags: []string{"pane"}}}},
+		},
+		{
+			name:   "child without spaces",
+			source: "#main>.pane",
+			want:   [][]compoundSelector{{{id: "main"}, {combinator: CHILD_COMBINATOR, tags: []string{"pane"}}}},
+		},
+		{
+			name:   "alternatives",
+			source: "#a, .b",
+			want:   [][]compoundSelector{{{id: "a"}}, {{tags: []string{"b"}}}},
+		},
+		{name: "empty", source: "", wantErr: true},
+		{name: "empty alternative", source: "#a,", wantErr: true},
+		{name: "leading child combinator", source: "> .pane", wantErr: true},
+		{name: "trailing child combinator", source: "#main >", wantErr: true},
+		{name: "doubled child combinator", source: "#main > > .pane", wantErr: true},
+		{name: "missing name", source: "#", wantErr: true},
+		{name: "two IDs", source: "#a#b", wantErr: true},
+		{name: "id after a tag", source: ".pane#a.b", want: [][]compoundSelector{{{id: "a", tags: []string{"pane", "b"}}}}},
+		{name: "invalid character", source: "#a!", wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := ParseSelector(c.source)
+			if c.wantErr {
+				if err == nil {
+					t.Errorf("ParseSelector(%q) = %v, want an error", c.source, got.alternatives)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSelector(%q) returned an error: %v", c.source, err)
+			}
+			if !reflect.DeepEqual(got.alternatives, c.want) {
+				t.Errorf("ParseSelector(%q) = %+v, want %+v", c.source, got.alternatives, c.want)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	editor := ComponentFromModel(testContainer{}).SetID("editor").SetTags("pane", "dirty")
+	tree := ComponentFromModel(testContainer{}).SetID("tree").SetTags("list")
+	side := ComponentFromModel(testContainer{components: []*Component{tree}}).SetID("side").SetTags("pane")
+	main := ComponentFromModel(testContainer{components: []*Component{editor, side}}).SetID("main").SetTags("pane")
+	status := ComponentFromModel(testContainer{}).SetID("status")
+	components := []*Component{main, status}
+	cases := []struct {
+		name     string
+		selector string
+		want     []*Component
+	}{
+		{name: "any", selector: "*", want: []*Component{main, editor, side, tree, status}},
+		{name: "id", selector: "#editor", want: []*Component{editor}},
+		{name: "tag in tree order", selector: ".pane", want: []*Component{main, editor, side}},
+		{name: "name matches an ID or a tag", selector: "list", want: []*Component{tree}},
+		{name: "compound", selector: ".pane.dirty", want: []*Component{editor}},
+		{name: "child", selector: "#main > .pane", want: []*Component{editor, side}},
+		{name: "child only matches direct components", selector: "#main > .list", want: nil},
+		{name: "descendant at any depth", selector: "#main .list", want: []*Component{tree}},
+		{name: "chained descendants", selector: "#main #side #tree", want: []*Component{tree}},
+		{name: "alternatives in tree order", selector: "#status, #side", want: []*Component{side, status}},
+		{name: "no matches", selector: "#missing", want: nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Query(components, c.selector)
+			if err != nil {
+				t.Fatalf("Query(%q) returned an error: %v", c.selector, err)
+			}
+			if !slices.Equal(got, c.want) {
+				var gotIDs, wantIDs []string
+				for _, component := range got {
+					gotIDs = append(gotIDs, component.GetID())
+				}
+				for _, component := range c.want {
+					wantIDs = append(wantIDs, component.GetID())
+				}
+				t.Errorf("Query(%q) = %v, want %v", c.selector, gotIDs, wantIDs)
+			}
+		})
+	}
+}
+
+func TestHandleSelectedMsg(t *testing.T) {
+	var selected, other []tea.Msg
+	components := []*Component{
+		ComponentFromModel(testModel{received: &selected}).SetTags("target"),
+		ComponentFromModel(testModel{received: &other}),
+	}
+	if cmd := HandleSelectedMsg(components, SelectedMsg{Selector: ".target", Msg: "hello"}); cmd != nil {
+		cmd()
+	}
+	if !slices.Equal(selected, []tea.Msg{"hello"}) || len(other) != 0 {
+		t.Errorf("the selected component received %v and the other one %v", selected, other)
+	}
+	cmd := HandleSelectedMsg(components, SelectedMsg{Selector: "> .target", Msg: "hello"})
+	if cmd == nil {
+		t.Fatal("an invalid selector wasn't reported")
+	}
+	if _, isError := cmd().(ErrorMsg); !isError {
+		t.Error("an invalid selector wasn't reported as an ErrorMsg")
+	}
+}
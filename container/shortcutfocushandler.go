@@ -0,0 +1,343 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// The keys that are given out (in order) when shortcuts are assigned automatically
+const AUTO_SHORTCUT_KEYS = "123456789abcdefghijklmnopqrstuvwxyz"
+
+// Shortcuts that are never assigned automatically, because Actions already use them
+var RESERVED_SHORTCUTS = []string{TOGGLE_ZOOM_KEY, TOGGLE_COLLAPSE_KEY, EXPAND_ALL_KEY}
+
+/*
+Returns the visible component (among the given components and the components nested
+inside of them) whose shortcut is the given key, or nil if there isn't one
+*/
+func GetComponentByShortcut(components []*Component, key string) *Component {
+	for _, component := range components {
+		if component == nil || component.IsHidden() {
+			continue
+		}
+		if key != "" && component.GetShortcut() == key {
+			return component
+		}
+		if component.IsCollapsed() {
+			continue
+		}
+		if cont, isCont := component.GetModel().(Container); isCont {
+			if output := GetComponentByShortcut(cont.GetVisibleComponents(), key); output != nil {
+				return output
+			}
+		}
+	}
+	return nil
+}
+
+/*
+Returns the components that share a shortcut with another of the given components
+(or with a component nested inside of them), keyed by the shortcut they share
+*/
+func GetDuplicateShortcuts(components []*Component) map[string][]*Component {
+	byShortcut := map[string][]*Component{}
+	WalkComponents(components, func(component *Component) {
+		if shortcut := component.GetShortcut(); shortcut != "" {
+			byShortcut[shortcut] = append(byShortcut[shortcut], component)
+		}
+	})
+	for shortcut, sharing := range byShortcut {
+		if len(sharing) < 2 {
+			delete(byShortcut, shortcut)
+		}
+	}
+	return byShortcut
+}
+
+/*
+Returns an error describing each shortcut that's shared by more than one of the given
+components (or the components nested inside of them), or nil if there aren't any
+*/
+func CheckShortcuts(components []*Component) error {
+	duplicates := GetDuplicateShortcuts(components)
+	var shortcuts []string
+	for shortcut := range duplicates {
+		shortcuts = append(shortcuts, shortcut)
+	}
+	slices.Sort(shortcuts)
+	var errs []error
+	for _, shortcut := range shortcuts {
+		var names []string
+		for _, component := range duplicates[shortcut] {
+			name := component.GetID()
+			if name == "" {
+				name = component.GetTitle()
+			}
+			names = append(names, fmt.Sprintf("%q", name))
+		}
+		errs = append(errs, fmt.Errorf("the shortcut %q is used by more than one component (%s)", shortcut, strings.Join(names, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+/*
+Gives each visible, focusable component (among the given components and the ones nested
+inside of them) that doesn't have a shortcut the next unused one of AUTO_SHORTCUT_KEYS, with
+the given prefix (e.g. "alt+"). Shortcuts for which isReserved returns true are skipped, as are
+RESERVED_SHORTCUTS. Returns the components that were given shortcuts
+*/
+func AssignShortcuts(components []*Component, prefix string, isReserved func(string) bool) (output []*Component) {
+	used := map[string]bool{}
+	WalkComponents(components, func(component *Component) {
+		used[component.GetShortcut()] = true
+	})
+	keys := []rune(AUTO_SHORTCUT_KEYS)
+	nextShortcut := func() string {
+		for len(keys) > 0 {
+			shortcut := prefix + string(keys[0])
+			keys = keys[1:]
+			if !used[shortcut] && !slices.Contains(RESERVED_SHORTCUTS, shortcut) && (isReserved == nil || !isReserved(shortcut)) {
+				return shortcut
+			}
+		}
+		return ""
+	}
+	for _, component := range GetAllFocusableComponents(components) {
+		if component.GetShortcut() != "" {
+			continue
+		}
+		shortcut := nextShortcut()
+		if shortcut == "" {
+			break
+		}
+		component.SetShortcut(shortcut).SetShowShortcut(true)
+		output = append(output, component)
+	}
+	return output
+}
+
+/*
+Implemented by FocusHandlers that check the shortcuts of their container's components
+for duplicates (like the one made by NewShortcutFocusHandler)
+*/
+type ShortcutChecker interface {
+	// Returns an error describing the shortcuts shared by more than one component, or nil if there aren't any
+	GetShortcutError() error
+}
+
+/*
+Returns the error reported by the given FocusHandler (or by a FocusHandler it wraps) about the
+shortcuts shared by more than one of its container's components (see ShortcutChecker), or nil
+*/
+func GetShortcutError(handler FocusHandler) error {
+	for handler != nil {
+		if checker, isChecker := handler.(ShortcutChecker); isChecker {
+			return checker.GetShortcutError()
+		}
+		wrapper, isWrapper := handler.(interface{ GetWrappedHandler() FocusHandler })
+		if !isWrapper {
+			return nil
+		}
+		handler = wrapper.GetWrappedHandler()
+	}
+	return nil
+}
+
+/*
+Wraps another FocusHandler, moving focus directly to a component when its shortcut
+is pressed (wherever it's nested among the container's components). Keys that the
+wrapped FocusHandler uses take precedence over the components' shortcuts
+*/
+type shortcutFocusHandler struct {
+	// The FocusHandler that handles every key that isn't one of the components' shortcuts
+	handler FocusHandler
+	// The function used to get the container's components (including ones that can't be focused)
+	componentDelegate func() []*Component
+	// The prefix of automatically assigned shortcuts (see AssignShortcuts), which is empty if they aren't assigned
+	autoAssignPrefix string
+	// The components the shortcuts were last checked against (shared by copies of the shortcutFocusHandler)
+	checked *shortcutCheck
+}
+
+/*
+The result of checking the components' shortcuts, which is only redone when the
+container's components change (not every time the component delegate is set)
+*/
+type shortcutCheck struct {
+	// The components the shortcuts were checked against
+	components []*Component
+	// The error describing the shortcuts shared by more than one component (see CheckShortcuts)
+	err error
+}
+
+/*
+Instantiates a shortcutFocusHandler that wraps the given FocusHandler. Containers only
+focus components by their shortcuts when they're given one (see SetFocusHandler), since
+the components' shortcuts are then kept from the focused component
+*/
+func NewShortcutFocusHandler(handler FocusHandler) shortcutFocusHandler {
+	return shortcutFocusHandler{handler: handler, checked: &shortcutCheck{}}
+}
+
+/*
+The same as NewShortcutFocusHandler(), but the focusable components that don't have a
+shortcut can be given one with the given prefix (see shortcutFocusHandler.AssignShortcuts).
+Returns an error if the prefix is empty, since shortcuts without one (like "1" or "a")
+would take those keys from the focused component
+*/
+func NewAutoShortcutFocusHandler(handler FocusHandler, prefix string) (shortcutFocusHandler, error) {
+	if strings.TrimSpace(prefix) == "" {
+		return shortcutFocusHandler{}, errors.New("automatically assigned shortcuts need a prefix (like \"alt+\")")
+	}
+	sfh := NewShortcutFocusHandler(handler)
+	sfh.autoAssignPrefix = prefix
+	return sfh, nil
+}
+
+/*
+Returns the FocusHandler wrapped by the shortcutFocusHandler
+*/
+func (sfh shortcutFocusHandler) GetWrappedHandler() FocusHandler {
+	return sfh.handler
+}
+
+/*
+Returns an error describing each shortcut that's shared by more than one of the container's
+components (see CheckShortcuts), as of when the shortcutFocusHandler was given to the container
+or its components last changed, or nil if there aren't any
+*/
+func (sfh shortcutFocusHandler) GetShortcutError() error {
+	if sfh.checked == nil {
+		return nil
+	}
+	return sfh.checked.err
+}
+
+/*
+Gives the focusable components (among the given ones, which should be the container's, and the
+ones nested inside of them) that don't have a shortcut one with the prefix the shortcutFocusHandler
+was made with (see NewAutoShortcutFocusHandler and AssignShortcuts), skipping the wrapped
+FocusHandler's keys, and checks the shortcuts for duplicates again. Components added to the
+container afterward aren't given shortcuts until this is called again. Does nothing if the
+shortcutFocusHandler has no prefix
+*/
+func (sfh shortcutFocusHandler) AssignShortcuts(components []*Component) shortcutFocusHandler {
+	if sfh.autoAssignPrefix == "" {
+		return sfh
+	}
+	if sfh.checked == nil {
+		sfh.checked = &shortcutCheck{}
+	}
+	AssignShortcuts(components, sfh.autoAssignPrefix, sfh.handler.IsFocusKey)
+	sfh.checked.components = slices.Clone(components)
+	sfh.checked.err = CheckShortcuts(components)
+	return sfh
+}
+
+/*
+Sets the component delegate function of the shortcutFocusHandler and of the FocusHandler it wraps.
+If the container's components changed since the last time, their shortcuts are checked for
+duplicates again
+*/
+func (sfh shortcutFocusHandler) SetComponentDelegate(componentDelegate func() []*Component) FocusHandler {
+	sfh.componentDelegate = componentDelegate
+	sfh.handler = sfh.handler.SetComponentDelegate(componentDelegate)
+	if sfh.checked == nil {
+		sfh.checked = &shortcutCheck{}
+	}
+	if componentDelegate == nil {
+		return sfh
+	}
+	// containers set the delegate every time their focus changes, so the tree is only walked when their components change
+	components := componentDelegate()
+	if sfh.checked.components != nil && slices.Equal(sfh.checked.components, components) {
+		return sfh
+	}
+	sfh.checked.components = slices.Clone(components)
+	sfh.checked.err = CheckShortcuts(components)
+	return sfh
+}
+
+/*
+Returns the component to focus when the given key is pressed, or nil if the key isn't a component's
+shortcut (or the component is outside of the active focus scope, see GetActiveFocusTrap)
+*/
+func (sfh shortcutFocusHandler) getShortcutTarget(key string) *Component {
+	if sfh.componentDelegate == nil {
+		return nil
+	}
+	target := GetComponentByShortcut(sfh.componentDelegate(), key)
+	if target == nil {
+		return nil
+	}
+	// a component that can't be focused itself (like a container) passes focus to the first component inside
+	// of it, as long as that's inside of the active focus scope (focus can't be sent out of the scope)
+	inScope := GetAllFocusableComponents(sfh.componentDelegate())
+	for _, component := range GetAllFocusableComponents([]*Component{target}) {
+		if slices.Contains(inScope, component) {
+			return component
+		}
+	}
+	return nil
+}
+
+/*
+Returns true if the given string is one of the components' shortcuts or a key
+combination that the wrapped FocusHandler uses
+*/
+func (sfh shortcutFocusHandler) IsFocusKey(key string) bool {
+	return sfh.handler.IsFocusKey(key) || sfh.getShortcutTarget(key) != nil
+}
+
+/*
+Passes keys that the wrapped FocusHandler uses on to it, otherwise focuses the
+component whose shortcut was pressed
+*/
+func (sfh shortcutFocusHandler) HandleFocusKey(key string) FocusHandler {
+	if sfh.handler.IsFocusKey(key) {
+		sfh.handler = sfh.handler.HandleFocusKey(key)
+		return sfh
+	}
+	if target := sfh.getShortcutTarget(key); target != nil {
+		sfh.handler = sfh.handler.SetFocusedComponent(target)
+	}
+	return sfh
+}
+
+/*
+Returns whether the wrapped FocusHandler would wrap focus around with the given key (see FocusWrapper)
+*/
+func (sfh shortcutFocusHandler) WouldWrap(key string) bool {
+	return sfh.handler.IsFocusKey(key) && wouldWrap(sfh.handler, key)
+}
+
+func (sfh shortcutFocusHandler) GetFocusedComponent() *Component {
+	return sfh.handler.GetFocusedComponent()
+}
+
+func (sfh shortcutFocusHandler) SetFocusedComponent(component *Component) FocusHandler {
+	sfh.handler = sfh.handler.SetFocusedComponent(component)
+	return sfh
+}
+
+/*
+Returns the overlay of the wrapped FocusHandler, if it has one
+*/
+func (sfh shortcutFocusHandler) RenderOverlay() string {
+	if overlay, hasOverlay := sfh.handler.(FocusOverlay); hasOverlay {
+		return overlay.RenderOverlay()
+	}
+	return ""
+}
+
+/*
+Returns the overlay revision of the wrapped FocusHandler, if it has an overlay
+*/
+func (sfh shortcutFocusHandler) GetOverlayRevision() uint64 {
+	if overlay, hasOverlay := sfh.handler.(FocusOverlay); hasOverlay {
+		return overlay.GetOverlayRevision()
+	}
+	return 0
+}
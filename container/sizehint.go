@@ -0,0 +1,102 @@
+package container
+
+import "github.com/argotnaut/vanitea/utils"
+
+const (
+	// The component shares the leftover space evenly with the other AUTO_SIZE components
+	AUTO_SIZE int = iota
+	// The component asks for an exact number of cells
+	FIXED_SIZE
+	// The component asks for a percentage of its container's space
+	PERCENT_SIZE
+	// The component shares the leftover space in proportion to its weight
+	WEIGHTED_SIZE
+)
+
+/*
+Describes how much space a Component asks for along the major axis of
+the container laying it out. The request is still subject to the
+Component's minimum and maximum dimensions
+*/
+type SizeHint struct {
+	// One of AUTO_SIZE, FIXED_SIZE, PERCENT_SIZE or WEIGHTED_SIZE
+	kind int
+	// The number of cells, the percentage or the weight (depending on the kind)
+	value int
+}
+
+/*
+Returns a SizeHint that shares leftover space evenly (the same as a weight of 1)
+*/
+func AutoSize() SizeHint {
+	return SizeHint{kind: AUTO_SIZE}
+}
+
+/*
+Returns a SizeHint asking for exactly the given number of cells
+*/
+func FixedSize(cells int) SizeHint {
+	return SizeHint{kind: FIXED_SIZE, value: max(0, cells)}
+}
+
+/*
+Returns a SizeHint asking for the given percentage of the container's space
+*/
+func PercentSize(percent int) SizeHint {
+	return SizeHint{kind: PERCENT_SIZE, value: utils.ClampInt(percent, 0, 100)}
+}
+
+/*
+Returns a SizeHint asking for a share of the leftover space in proportion to the given weight
+*/
+func WeightedSize(weight int) SizeHint {
+	return SizeHint{kind: WEIGHTED_SIZE, value: max(0, weight)}
+}
+
+/*
+Returns the kind of the SizeHint (AUTO_SIZE, FIXED_SIZE, PERCENT_SIZE or WEIGHTED_SIZE)
+*/
+func (h SizeHint) GetKind() int {
+	return h.kind
+}
+
+/*
+Returns the number of cells, the percentage or the weight of the SizeHint (depending on its kind)
+*/
+func (h SizeHint) GetValue() int {
+	return h.value
+}
+
+/*
+Returns whether the SizeHint asks for a specific amount of space (as opposed to a share of the leftover space)
+*/
+func (h SizeHint) IsExact() bool {
+	return h.kind == FIXED_SIZE || h.kind == PERCENT_SIZE
+}
+
+/*
+Returns the number of cells an exact SizeHint asks for, given the space available to
+the container (returns 0 for SizeHints that share leftover space)
+*/
+func (h SizeHint) Resolve(available int) int {
+	switch h.kind {
+	case FIXED_SIZE:
+		return h.value
+	case PERCENT_SIZE:
+		return max(0, available) * h.value / 100
+	}
+	return 0
+}
+
+/*
+Returns the weight the SizeHint has when sharing leftover space (exact SizeHints don't share leftover space)
+*/
+func (h SizeHint) GetWeight() int {
+	switch h.kind {
+	case AUTO_SIZE:
+		return 1
+	case WEIGHTED_SIZE:
+		return h.value
+	}
+	return 0
+}
@@ -0,0 +1,222 @@
+package container
+
+import (
+	"math"
+	"slices"
+)
+
+/*
+Contains the slices of keyboard shortcuts that correspond to moving the focus in
+each direction in a spatialFocusHandler, along with the shortcuts that move it
+forward and backward through the list of focusable components
+*/
+type SpatialFocusKeyMap struct {
+	FocusLeft     []string
+	FocusRight    []string
+	FocusUp       []string
+	FocusDown     []string
+	FocusForward  []string
+	FocusBackward []string
+}
+
+/*
+Returns whether the SpatialFocusKeyMap includes the provided string
+(representing a keyboard shortcut) in any of its slices
+*/
+func (km SpatialFocusKeyMap) Contains(input string) bool {
+	return slices.Contains(km.FocusLeft, input) ||
+		slices.Contains(km.FocusRight, input) ||
+		slices.Contains(km.FocusUp, input) ||
+		slices.Contains(km.FocusDown, input) ||
+		slices.Contains(km.FocusForward, input) ||
+		slices.Contains(km.FocusBackward, input)
+}
+
+/*
+Instantiates a SpatialFocusKeyMap with default keyboard shortcuts
+*/
+func NewDefaultSpatialFocusKeyMap() SpatialFocusKeyMap {
+	return SpatialFocusKeyMap{
+		FocusLeft:     []string{"ctrl+left", "alt+h"},
+		FocusRight:    []string{"ctrl+right", "alt+l"},
+		FocusUp:       []string{"ctrl+up", "alt+k"},
+		FocusDown:     []string{"ctrl+down", "alt+j"},
+		FocusForward:  []string{FOCUS_FORWARD},
+		FocusBackward: []string{FOCUS_BACKWARD},
+	}
+}
+
+/*
+Handles the transfer of focus from one of a container's components to the nearest
+one in the direction of a key press, based on where the components are on screen.
+Components that haven't been laid out yet (and so have no geometry) are focused in
+the order given by the component delegate instead, as a linearFocusHandler would
+*/
+type spatialFocusHandler struct {
+	// A pointer to the currently focused component
+	focusedComponent *Component
+	// The key combinations that can be pressed to affect focus
+	keyMap SpatialFocusKeyMap
+	// The function used to get the slice of components whose focus is being handled
+	componentDelegate func() []*Component
+}
+
+/*
+Instantiates a spatialFocusHandler with the default settings and
+the given subject component delegate function
+*/
+func NewDefaultSpatialFocusHandler(delegate func() []*Component) spatialFocusHandler {
+	return NewSpatialFocusHandler(NewDefaultSpatialFocusKeyMap(), delegate)
+}
+
+/*
+Instantiates a new spatialFocusHandler with the given SpatialFocusKeyMap and
+focus component delegate function
+*/
+func NewSpatialFocusHandler(keyMap SpatialFocusKeyMap, delegate func() []*Component) spatialFocusHandler {
+	return spatialFocusHandler{
+		keyMap:            keyMap,
+		componentDelegate: delegate,
+	}
+}
+
+/*
+Sets the focusable component delegate function of the spatialFocusHandler (which
+can give focus to the focusable components nested inside of the delegate's components)
+*/
+func (sfh spatialFocusHandler) SetComponentDelegate(componentDelegate func() []*Component) FocusHandler {
+	sfh.componentDelegate = func() []*Component { return GetAllFocusableComponents(componentDelegate()) }
+	if sfh.focusedComponent == nil && componentDelegate != nil && len(sfh.componentDelegate()) > 0 {
+		sfh.focusedComponent = sfh.componentDelegate()[0]
+	}
+	return sfh
+}
+
+/*
+Returns true if the given string represents a key combination that can affect focus
+*/
+func (sfh spatialFocusHandler) IsFocusKey(key string) bool {
+	return sfh.keyMap.Contains(key)
+}
+
+/*
+Returns a pointer to the Component that currently has focus
+*/
+func (sfh spatialFocusHandler) GetFocusedComponent() *Component {
+	return sfh.focusedComponent
+}
+
+/*
+Sets the spatialFocusHandler's pointer to the focused component to the given pointer
+*/
+func (sfh spatialFocusHandler) SetFocusedComponent(component *Component) FocusHandler {
+	sfh.focusedComponent = component
+	return sfh
+}
+
+/*
+Moves focus according to the spatialFocusHandler and the given keyboard shortcut string
+*/
+func (sfh spatialFocusHandler) HandleFocusKey(key string) FocusHandler {
+	switch {
+	case slices.Contains(sfh.keyMap.FocusLeft, key):
+		return sfh.focusToward(-1, 0)
+	case slices.Contains(sfh.keyMap.FocusRight, key):
+		return sfh.focusToward(1, 0)
+	case slices.Contains(sfh.keyMap.FocusUp, key):
+		return sfh.focusToward(0, -1)
+	case slices.Contains(sfh.keyMap.FocusDown, key):
+		return sfh.focusToward(0, 1)
+	case slices.Contains(sfh.keyMap.FocusForward, key):
+		return sfh.shiftFocus(1)
+	case slices.Contains(sfh.keyMap.FocusBackward, key):
+		return sfh.shiftFocus(-1)
+	}
+	return sfh
+}
+
+/*
+Returns whether the given key would send focus from the last component back to the first
+(or from the first to the last). Moving focus in a direction never wraps around
+*/
+func (sfh spatialFocusHandler) WouldWrap(key string) bool {
+	components := sfh.componentDelegate()
+	idx := slices.Index(components, sfh.GetFocusedComponent())
+	if idx < 0 {
+		return false
+	}
+	return (slices.Contains(sfh.keyMap.FocusForward, key) && idx == len(components)-1) ||
+		(slices.Contains(sfh.keyMap.FocusBackward, key) && idx == 0)
+}
+
+/*
+Shifts focus through the list of focusable components by the given number
+*/
+func (sfh spatialFocusHandler) shiftFocus(displacement int) FocusHandler {
+	components := sfh.componentDelegate()
+	if len(components) < 1 {
+		return sfh
+	}
+	newIndex := 0
+	if idx := slices.Index(components, sfh.GetFocusedComponent()); idx >= 0 {
+		newIndex = (idx + displacement + len(components)) % len(components)
+	}
+	return sfh.SetFocusedComponent(components[newIndex])
+}
+
+/*
+Moves focus to the nearest focusable component in the given direction (dx and dy are each
+-1, 0 or 1), leaving it where it is if there isn't one. If the focused component has no
+geometry, focus moves forward (right or down) or backward (left or up) through the list
+*/
+func (sfh spatialFocusHandler) focusToward(dx int, dy int) FocusHandler {
+	focused := sfh.GetFocusedComponent()
+	if focused == nil || focused.GetFrame().IsEmpty() {
+		return sfh.shiftFocus(dx + dy)
+	}
+	from := focused.GetFrame()
+	var nearest *Component
+	nearestScore := math.MaxInt
+	for _, candidate := range sfh.componentDelegate() {
+		to := candidate.GetFrame()
+		if candidate == focused || to.IsEmpty() {
+			continue
+		}
+		if score, ok := getDirectionalDistance(from, to, dx, dy); ok && score < nearestScore {
+			nearest, nearestScore = candidate, score
+		}
+	}
+	if nearest == nil {
+		return sfh
+	}
+	return sfh.SetFocusedComponent(nearest)
+}
+
+/*
+Returns how far the "to" rectangle is from the "from" rectangle in the given direction
+(weighting the distance along the direction more heavily than any misalignment across it),
+or false if "to" doesn't lie entirely beyond the edge of "from" in that direction
+*/
+func getDirectionalDistance(from Rectangle, to Rectangle, dx int, dy int) (int, bool) {
+	var gap int
+	switch {
+	case dx > 0:
+		gap = to.X - (from.X + from.Width)
+	case dx < 0:
+		gap = from.X - (to.X + to.Width)
+	case dy > 0:
+		gap = to.Y - (from.Y + from.Height)
+	default:
+		gap = from.Y - (to.Y + to.Height)
+	}
+	if gap < 0 {
+		return 0, false
+	}
+	// the distance between the two rectangles across the direction (zero if they overlap along that axis)
+	fromStart, fromEnd, toStart, toEnd := from.Y, from.Y+from.Height, to.Y, to.Y+to.Height
+	if dx == 0 {
+		fromStart, fromEnd, toStart, toEnd = from.X, from.X+from.Width, to.X, to.X+to.Width
+	}
+	misalignment := max(0, toStart-fromEnd, fromStart-toEnd)
+	return gap*2 + misalignment, true
+}
@@ -0,0 +1,150 @@
+package container
+
+import (
+	"maps"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	TOGGLE_ZOOM_KEY = "alt+z"
+	// Rendered next to the title of a zoomed component
+	ZOOMED_INDICATOR = "[zoomed]"
+)
+
+/*
+What a container's components looked like before one of them was zoomed,
+so that they can be restored exactly when the zoom is toggled off
+*/
+type zoomRecord struct {
+	// Whether each of the container's components was hidden
+	hidden map[*Component]bool
+	// The size of each of the container's components
+	sizes map[*Component]tea.WindowSizeMsg
+}
+
+/*
+Returns whether the Component has been zoomed to fill its container
+*/
+func (m Component) IsZoomed() bool {
+	return m.zoom != nil
+}
+
+/*
+Returns the hidden flag each of the components of the Component's container had before the
+Component was zoomed (which Unzoom restores), or nil if the Component isn't zoomed
+*/
+func (m Component) GetHiddenBeforeZoom() map[*Component]bool {
+	if m.zoom == nil {
+		return nil
+	}
+	return maps.Clone(m.zoom.hidden)
+}
+
+/*
+Returns the zoomed component among the given components, or nil if none of them are zoomed
+*/
+func GetZoomedComponent(components []*Component) *Component {
+	for _, component := range components {
+		if component != nil && component.IsZoomed() {
+			return component
+		}
+	}
+	return nil
+}
+
+/*
+Hides every one of the given components except the target, so that the target gets all
+of the container's space when it's next laid out. The hidden flags and sizes of all of the
+components are recorded so that Unzoom can restore them
+*/
+func Zoom(components []*Component, target *Component) {
+	Unzoom(components)
+	if target == nil {
+		return
+	}
+	record := &zoomRecord{
+		hidden: map[*Component]bool{},
+		sizes:  map[*Component]tea.WindowSizeMsg{},
+	}
+	for _, component := range components {
+		record.hidden[component] = component.IsHidden()
+		record.sizes[component] = component.GetSize()
+		component.SetHidden(component != target)
+	}
+	target.zoom = record
+}
+
+/*
+Restores the hidden flags and sizes the given components had before one of them was zoomed
+*/
+func Unzoom(components []*Component) {
+	zoomed := GetZoomedComponent(components)
+	if zoomed == nil {
+		return
+	}
+	record := zoomed.zoom
+	zoomed.zoom = nil
+	for component, hidden := range record.hidden {
+		component.SetHidden(hidden)
+	}
+	for component, size := range record.sizes {
+		component.SetSize(size)
+	}
+}
+
+/*
+Zooms the target if none of the given components are zoomed, otherwise restores them
+*/
+func ToggleZoom(components []*Component, target *Component) {
+	if GetZoomedComponent(components) != nil {
+		Unzoom(components)
+	} else {
+		Zoom(components, target)
+	}
+}
+
+/*
+Returns an Action that toggles whether the focused component of the container with the given
+ChangeQueue is zoomed to fill the container. The change is queued for the container's latest
+copy (see ChangeQueue), so any container can use it
+*/
+func NewToggleZoomAction(changes *ChangeQueue) Action {
+	toggle := func(*Component) {
+		changes.Queue(func(container Container) {
+			components := container.GetComponents()
+			target := GetZoomedComponent(components)
+			if target == nil && container.GetFocusHandler() != nil {
+				target = GetChildContaining(components, container.GetFocusHandler().GetFocusedComponent())
+			}
+			ToggleZoom(components, target)
+		})
+	}
+	return NewDefaultAction(
+		"toggle zoom",
+		"Give the focused pane all of its container's space, or restore the other panes",
+		TOGGLE_ZOOM_KEY,
+		nil,
+		toggle,
+		toggle,
+	)
+}
+
+/*
+Returns the component among the given components that either is the target or
+contains it (in the components of its model, if the model is a Container)
+*/
+func GetChildContaining(components []*Component, target *Component) *Component {
+	if target == nil {
+		return nil
+	}
+	for _, component := range components {
+		if component == target {
+			return component
+		}
+		if cont, isCont := component.GetModel().(Container); isCont && GetChildContaining(cont.GetComponents(), target) != nil {
+			return component
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	con "github.com/argotnaut/vanitea/container"
+	gc "github.com/argotnaut/vanitea/gridcontainer"
+	placeholder "github.com/argotnaut/vanitea/placeholder"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func main() {
+	styleBlue := lipgloss.NewStyle().Background(lipgloss.Color("#648fff"))
+	styleMagenta := lipgloss.NewStyle().Background(lipgloss.Color("#dc267f"))
+	styleLavender := lipgloss.NewStyle().Background(lipgloss.Color("#785ef0"))
+	styleYellow := lipgloss.NewStyle().Background(lipgloss.Color("#ffb000"))
+
+	grid := gc.NewGridContainer(
+		[]gc.TrackSize{gc.Fixed(5), gc.Fraction(1), gc.Fraction(2)},
+		[]gc.TrackSize{gc.Auto(), gc.Fraction(1), gc.Fraction(1)},
+	)
+	grid.AddComponent(
+		con.ComponentFromModel(
+			placeholder.GetPlaceholder(&styleMagenta, nil, nil, nil),
+		).SetTitle("header").SetShowTitle(true),
+		0, 0, 1, 3,
+	).AddComponent(
+		con.ComponentFromModel(
+			placeholder.GetPlaceholder(&styleBlue, nil, nil, nil),
+		).SetTitle("sidebar").SetShowTitle(true).SetMaximumWidth(20),
+		1, 0, 2, 1,
+	).AddComponent(
+		con.ComponentFromModel(
+			placeholder.GetPlaceholder(&styleLavender, nil, nil, nil),
+		).SetTitle("top").SetShowTitle(true),
+		1, 1, 1, 2,
+	).AddComponent(
+		con.ComponentFromModel(
+			placeholder.GetPlaceholder(&styleYellow, nil, nil, nil),
+		).SetTitle("main").SetShowTitle(true),
+		2, 1, 1, 2,
+	)
+
+	// move focus between the grid's cells with ctrl+arrows or alt+hjkl
+	grid.SetFocusHandler(con.NewDefaultMRUFocusHandler(con.NewDefaultSpatialFocusHandler(grid.GetComponents)))
+
+	_, err := tea.NewProgram(grid, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run()
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/argotnaut/vanitea/layout"
+	placeholder "github.com/argotnaut/vanitea/placeholder"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func main() {
+	registry := layout.NewRegistry().Register(
+		"placeholder",
+		func(rawOptions json.RawMessage) (tea.Model, error) {
+			var options struct {
+				Color string `json:"color"`
+			}
+			if rawOptions != nil {
+				if err := json.Unmarshal(rawOptions, &options); err != nil {
+					return nil, err
+				}
+			}
+			style := lipgloss.NewStyle().Background(lipgloss.Color(options.Color))
+			return placeholder.GetPlaceholder(&style, nil, nil, nil), nil
+		},
+	)
+
+	appFrame, err := layout.LoadAppFrame("examples/layout/layout.json", registry)
+	if err != nil {
+		panic(err)
+	}
+	_, err = tea.NewProgram(appFrame, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run()
+	if err != nil {
+		panic(err)
+	}
+}
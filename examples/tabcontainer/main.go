@@ -0,0 +1,46 @@
+package main
+
+import (
+	con "github.com/argotnaut/vanitea/container"
+	lc "github.com/argotnaut/vanitea/linearcontainer"
+	placeholder "github.com/argotnaut/vanitea/placeholder"
+	tc "github.com/argotnaut/vanitea/tabcontainer"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func main() {
+	styleBlue := lipgloss.NewStyle().Background(lipgloss.Color("#648fff"))
+	styleMagenta := lipgloss.NewStyle().Background(lipgloss.Color("#dc267f"))
+	styleLavender := lipgloss.NewStyle().Background(lipgloss.Color("#785ef0"))
+	styleYellow := lipgloss.NewStyle().Background(lipgloss.Color("#ffb000"))
+
+	split := lc.NewLinearContainerFromComponents([]*con.Component{
+		con.ComponentFromModel(
+			placeholder.GetPlaceholder(&styleLavender, nil, nil, nil),
+		).SetTitle("left"),
+		con.ComponentFromModel(
+			placeholder.GetPlaceholder(&styleYellow, nil, nil, nil),
+		).SetTitle("right"),
+	})
+
+	tabs := tc.NewTabContainerFromComponents([]*con.Component{
+		con.ComponentFromModel(
+			placeholder.GetPlaceholder(&styleBlue, nil, nil, nil),
+		).SetTitle("first").SetShortcut("alt+1"),
+		con.ComponentFromModel(
+			placeholder.GetPlaceholder(&styleMagenta, nil, nil, nil),
+		).SetTitle("second").SetShortcut("alt+2"),
+		con.ComponentFromModel(*split).SetTitle("split").SetShortcut("alt+3").SetFocusBorderStyle(con.NO_BORDER_STYLE).SetBorderStyle(con.NO_BORDER_STYLE),
+	})
+	// the tabs' nested components are focused directly by their shortcuts
+	tabs.SetFocusHandler(con.NewShortcutFocusHandler(con.NewDefaultLinearFocusHandler(tabs.GetComponents)))
+	if err := con.GetShortcutError(tabs.GetFocusHandler()); err != nil {
+		panic(err)
+	}
+
+	_, err := tea.NewProgram(tabs, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run()
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,501 @@
+package gridcontainer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	con "github.com/argotnaut/vanitea/container"
+	"github.com/argotnaut/vanitea/utils"
+)
+
+/*
+The placement of a component within a GridContainerModel
+*/
+type GridCell struct {
+	// The component placed in the grid
+	Component *con.Component
+	// The index of the first row the component occupies
+	Row int
+	// The index of the first column the component occupies
+	Column int
+	// The number of rows the component spans
+	RowSpan int
+	// The number of columns the component spans
+	ColumnSpan int
+}
+
+/*
+A container that lays its components out in rows and columns, where each
+component may span several rows and/or columns
+*/
+type GridContainerModel struct {
+	focusHandler con.FocusHandler
+	// The components of the grid and where they are placed, in grid order (by row, then column)
+	cells []*GridCell
+	// The sizing rules for each row
+	rows []TrackSize
+	// The sizing rules for each column
+	columns []TrackSize
+	// The area available to the grid
+	size tea.WindowSizeMsg
+	// The grid's last rendering (shared by copies of the grid)
+	viewCache *con.RenderCache
+	// The changes the grid's Actions queued up for it (shared by copies of the grid)
+	changes *con.ChangeQueue
+}
+
+/*
+Instantiates a GridContainerModel with the given row and column sizing rules
+*/
+func NewGridContainer(rows []TrackSize, columns []TrackSize) *GridContainerModel {
+	gc := GridContainerModel{
+		rows:      rows,
+		columns:   columns,
+		viewCache: &con.RenderCache{},
+		changes:   &con.ChangeQueue{},
+	}
+	gc.SetFocusHandler(con.NewDefaultLinearFocusHandler(gc.GetComponents))
+	return &gc
+}
+
+/*
+Places the given component in the grid, with its top-left corner in the given row
+and column, spanning the given number of rows and columns. Components are kept in
+grid order, which is also the order in which focus moves between them. A component
+whose top-left corner is outside of the grid's rows and columns (or that's nil, or
+already in the grid) isn't added
+*/
+func (m *GridContainerModel) AddComponent(component *con.Component, row int, column int, rowSpan int, columnSpan int) *GridContainerModel {
+	cell := m.newCell(component, row, column, rowSpan, columnSpan)
+	if component == nil || cell == nil || m.GetCell(component) != nil {
+		return m
+	}
+	// focus stays on the first focusable component in grid order unless it was moved elsewhere
+	focusWasDefault := m.GetFocusHandler().GetFocusedComponent() == m.getFirstFocusableComponent()
+	idx := m.getCellIndex(cell)
+	m.cells = con.Splice(m.cells, idx, idx, cell)
+	// the focus handler's delegate holds a copy of the old list of cells, so it needs to be refreshed
+	m.SetFocusHandler(m.GetFocusHandler())
+	if focusWasDefault {
+		m.SetFocusHandler(m.GetFocusHandler().SetFocusedComponent(m.getFirstFocusableComponent()))
+	}
+	return m
+}
+
+/*
+Returns the placement of the given component with its top-left corner in the given row and column,
+spanning the given number of rows and columns (at least one of each), or nil if the top-left corner
+is outside of the grid's rows and columns
+*/
+func (m GridContainerModel) newCell(component *con.Component, row int, column int, rowSpan int, columnSpan int) *GridCell {
+	if row < 0 || row >= len(m.rows) || column < 0 || column >= len(m.columns) {
+		return nil
+	}
+	return &GridCell{
+		Component:  component,
+		Row:        row,
+		Column:     column,
+		RowSpan:    max(1, rowSpan),
+		ColumnSpan: max(1, columnSpan),
+	}
+}
+
+/*
+Returns the index in grid order at which the given cell belongs (components that share
+a starting cell are placed after the ones that were added earlier)
+*/
+func (m GridContainerModel) getCellIndex(cell *GridCell) int {
+	idx, _ := slices.BinarySearchFunc(m.cells, cell, func(a *GridCell, b *GridCell) int {
+		if a.Row != b.Row {
+			return a.Row - b.Row
+		}
+		if a.Column <= b.Column {
+			return -1
+		}
+		return 1
+	})
+	return idx
+}
+
+/*
+Places the given component (which is already in the grid) with its top-left corner in the given
+row and column, spanning the given number of rows and columns, as AddComponent would place it. The
+component stays where it is if the new top-left corner is outside of the grid's rows and columns
+*/
+func (m *GridContainerModel) MoveComponent(component *con.Component, row int, column int, rowSpan int, columnSpan int) *GridContainerModel {
+	idx := slices.Index(m.GetComponents(), component)
+	cell := m.newCell(component, row, column, rowSpan, columnSpan)
+	if idx < 0 || cell == nil {
+		return m
+	}
+	m.cells = con.Splice(m.cells, idx, idx+1)
+	newIdx := m.getCellIndex(cell)
+	m.cells = con.Splice(m.cells, newIdx, newIdx, cell)
+	// the focus handler's delegate holds a copy of the old list of cells, so it needs to be refreshed
+	m.SetFocusHandler(m.GetFocusHandler())
+	return m
+}
+
+/*
+Removes the given component from the grid, returning the tea.Cmd from unmounting it (see
+con.Component.Unmount). If the focused component was removed, focus returns to where it was
+before it was trapped inside of the removed component, or else moves to the first component
+that can receive it
+*/
+func (m *GridContainerModel) RemoveComponent(component *con.Component) tea.Cmd {
+	idx := slices.Index(m.GetComponents(), component)
+	if idx < 0 {
+		return nil
+	}
+	cmd := con.RemoveComponent(m, component, func() {
+		m.cells = con.Splice(m.cells, idx, idx+1)
+	})
+	m.ensureValidFocus()
+	return cmd
+}
+
+/*
+Puts the given replacement in the cells spanned by the given component (which is removed as by
+RemoveComponent), returning the tea.Cmd from unmounting the replaced component. If the replaced
+component had focus, the replacement gets it (if it can receive it)
+*/
+func (m *GridContainerModel) ReplaceComponent(component *con.Component, replacement *con.Component) tea.Cmd {
+	cell := m.GetCell(component)
+	if cell == nil || replacement == nil {
+		return nil
+	}
+	cmd := con.ReplaceComponent(m, component, replacement, func() tea.Cmd {
+		cmd := m.RemoveComponent(component)
+		m.AddComponent(replacement, cell.Row, cell.Column, cell.RowSpan, cell.ColumnSpan)
+		return cmd
+	})
+	m.ensureValidFocus()
+	return cmd
+}
+
+/*
+Changes the grid's components as the given message describes (if it targets this container,
+otherwise it's passed on to the nested container it targets) and lays them out again. Only
+removing and replacing components is supported, since where a component is placed in the
+grid depends on its cells rather than on an index (see AddComponent). Inserting and moving
+components are reported as errors (see con.ErrorMsg)
+*/
+func (m *GridContainerModel) handleComponentsMsg(msg con.ComponentsMsg) tea.Cmd {
+	if msg.GetContainerID() != "" {
+		cmd, handler := con.ForwardComponentsMsg(m.GetComponents(), m.GetFocusHandler(), msg)
+		m.SetFocusHandler(handler)
+		m.ensureValidFocus()
+		return cmd
+	}
+	var cmd tea.Cmd
+	getCellComponent := func(id string) *con.Component {
+		for _, component := range m.GetComponents() {
+			if id != "" && component.GetID() == id {
+				return component
+			}
+		}
+		return nil
+	}
+	switch msg := msg.(type) {
+	case con.RemoveComponentMsg:
+		cmd = m.RemoveComponent(getCellComponent(msg.ComponentID))
+	case con.ReplaceComponentMsg:
+		cmd = m.ReplaceComponent(getCellComponent(msg.ComponentID), msg.Component)
+	default:
+		return con.ReportError(fmt.Errorf("grid containers place components by their cells, so they can't handle a %T", msg))
+	}
+	return tea.Batch(cmd, con.RelayoutContainer(m, m.size))
+}
+
+/*
+Returns the first component in grid order that can receive focus, or nil if there isn't one
+*/
+func (m GridContainerModel) getFirstFocusableComponent() *con.Component {
+	focusable := con.GetAllFocusableComponents(m.GetComponents())
+	if len(focusable) < 1 {
+		return nil
+	}
+	return focusable[0]
+}
+
+/*
+Moves focus to the first component that can receive it if the focused component can't (because
+it was hidden or collapsed, or because a focus scope was opened or closed). Focus that was given
+to a component outside of the GridContainerModel (by a parent container) is left alone
+*/
+func (m *GridContainerModel) ensureValidFocus() {
+	con.EnsureValidFocus(m, m.GetComponents())
+}
+
+/*
+Returns the placements of the grid's components, in grid order
+*/
+func (m GridContainerModel) GetCells() []*GridCell {
+	return m.cells
+}
+
+/*
+Returns the placement of the given component, or nil if it isn't in the grid
+*/
+func (m GridContainerModel) GetCell(component *con.Component) *GridCell {
+	for _, cell := range m.cells {
+		if cell.Component == component {
+			return cell
+		}
+	}
+	return nil
+}
+
+func (m GridContainerModel) GetRows() []TrackSize {
+	return m.rows
+}
+
+func (m *GridContainerModel) SetRows(rows []TrackSize) *GridContainerModel {
+	m.rows = rows
+	return m
+}
+
+func (m GridContainerModel) GetColumns() []TrackSize {
+	return m.columns
+}
+
+func (m *GridContainerModel) SetColumns(columns []TrackSize) *GridContainerModel {
+	m.columns = columns
+	return m
+}
+
+func (m GridContainerModel) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, component := range m.GetComponents() {
+		cmds = append(cmds, component.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m GridContainerModel) GetComponents() (output []*con.Component) {
+	for _, cell := range m.cells {
+		output = append(output, cell.Component)
+	}
+	return
+}
+
+func (m GridContainerModel) GetVisibleComponents() (output []*con.Component) {
+	for _, component := range m.GetComponents() {
+		if !component.IsHidden() {
+			output = append(output, component)
+		}
+	}
+	return
+}
+
+func (m GridContainerModel) GetActions() (output []con.Action) {
+	for _, component := range m.GetComponents() {
+		output = append(output, component.GetActions()...)
+	}
+	// only offer zooming when the focused component belongs to the grid directly
+	if focused := m.GetFocusHandler().GetFocusedComponent(); focused != nil && m.GetCell(focused) != nil {
+		output = append(output, con.NewToggleZoomAction(m.changes))
+	}
+	return output
+}
+
+func (m *GridContainerModel) SetFocusHandler(handler con.FocusHandler) {
+	m.focusHandler = handler.SetComponentDelegate(m.GetComponents)
+}
+
+func (m GridContainerModel) GetFocusHandler() con.FocusHandler {
+	return m.focusHandler
+}
+
+/*
+Returns the GridContainerModel with the given component as its focused component (see con.FocusSyncable)
+*/
+func (m GridContainerModel) SyncFocus(focused *con.Component) tea.Model {
+	if m.GetFocusHandler() != nil {
+		m.SetFocusHandler(m.GetFocusHandler().SetFocusedComponent(focused))
+	}
+	return m
+}
+
+/*
+Returns the current border style of the given component
+*/
+func (m GridContainerModel) GetComponentStyle(component *con.Component) lipgloss.Style {
+	if component == nil {
+		return con.NO_BORDER_STYLE
+	}
+	return component.GetCurrentBorderStyle(m.GetFocusHandler().GetFocusedComponent() == component)
+}
+
+/*
+Returns the size of each row and column of the grid, given the area available to it
+*/
+func (m GridContainerModel) GetTrackSizes(containerSize tea.WindowSizeMsg) (rowSizes []int, columnSizes []int) {
+	rowSizes = solveTracks(m.rows, containerSize.Height, m.cells, m.GetComponentStyle, false)
+	columnSizes = solveTracks(m.columns, containerSize.Width, m.cells, m.GetComponentStyle, true)
+	return
+}
+
+/*
+Returns the size the grid's tracks take up within the given constraint, along with the smallest
+and largest sizes they can take up (see getTrackBounds)
+*/
+func (m GridContainerModel) GetContentSize(constraint tea.WindowSizeMsg) con.ContentSize {
+	var output con.ContentSize
+	output.Minimum.Height, output.Maximum.Height = getTrackBounds(m.rows, m.cells, m.GetComponentStyle, false)
+	output.Minimum.Width, output.Maximum.Width = getTrackBounds(m.columns, m.cells, m.GetComponentStyle, true)
+	output.Preferred = tea.WindowSizeMsg{
+		Width:  min(constraint.Width, output.Maximum.Width),
+		Height: min(constraint.Height, output.Maximum.Height),
+	}
+	return output
+}
+
+/*
+Sizes the rows and columns of the grid to fit the given area, then resizes each
+component to fill the cells it spans (as far as its minimum and maximum
+dimensions allow)
+*/
+func (m *GridContainerModel) ResizeComponents(containerSize tea.WindowSizeMsg) tea.Cmd {
+	m.size = containerSize
+	rowSizes, columnSizes := m.GetTrackSizes(containerSize)
+
+	var cmds []tea.Cmd
+	zoomed := con.GetZoomedComponent(m.GetComponents())
+	for _, cell := range m.cells {
+		area := cellBounds(cell, rowSizes, columnSizes)
+		component := cell.Component
+		if component == zoomed {
+			// a zoomed component gets the whole grid, regardless of the cells it spans
+			area = con.Rectangle{Width: containerSize.Width, Height: containerSize.Height}
+		}
+		style := m.GetComponentStyle(component)
+		size := component.GetLayoutClampedSize(tea.WindowSizeMsg{Width: area.Width, Height: area.Height}, style)
+		model, cmd := component.GetModel().Update(tea.WindowSizeMsg{
+			Width:  size.Width - style.GetHorizontalFrameSize(),
+			Height: size.Height - style.GetVerticalFrameSize(),
+		})
+		component.SetSize(size)
+		component.SetModel(model)
+		component.SetLayout(con.Rectangle{X: area.X, Y: area.Y, Width: size.Width, Height: size.Height}, style)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+/*
+Returns the size of the area the grid's tracks occupy
+*/
+func (m GridContainerModel) GetFullContainerSize() (output tea.WindowSizeMsg) {
+	if con.GetZoomedComponent(m.GetComponents()) != nil {
+		return m.size
+	}
+	rowSizes, columnSizes := m.GetTrackSizes(m.size)
+	for _, size := range rowSizes {
+		output.Height += size
+	}
+	for _, size := range columnSizes {
+		output.Width += size
+	}
+	return
+}
+
+/*
+Routes the given tea.MouseMsg to the visible component under the cursor (with its
+coordinates translated into that component's space) and, if the message is a click,
+moves focus to the most deeply nested focusable component under the cursor
+*/
+func (m *GridContainerModel) handleMouseMsg(msg tea.MouseMsg) tea.Cmd {
+	return con.RouteMouseMsg(m, msg, nil)
+}
+
+func (m GridContainerModel) ViewComponent(component *con.Component) string {
+	if m.GetFocusHandler().GetFocusedComponent() == component {
+		return component.RenderFocused()
+	}
+	return component.RenderBlurred()
+}
+
+/*
+Updates the GridContainerModel and its components with the given message, then sends the components the
+lifecycle messages (see con.DeliverLifecycle) and delivers focus (see con.DeliverFocus)
+in case the message laid them out, showed or hid them, or changed which one has focus
+*/
+func (m GridContainerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return con.UpdateContainer(m, msg, m.update)
+}
+
+func (m GridContainerModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	con.ApplyChanges(m.changes, &m)
+	m.ensureValidFocus()
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if handler, isFocusKey := con.HandleNestedFocusKey(m.GetComponents(), m.GetFocusHandler(), msg.String()); isFocusKey {
+			m.SetFocusHandler(handler)
+			return m, con.GetFocusOverlayCmd(m.GetFocusHandler())
+		}
+		return m, con.ForwardKey(m.GetComponents(), m.GetFocusHandler(), msg)
+	case tea.WindowSizeMsg:
+		return m, (&m).ResizeComponents(msg)
+	case con.RelayoutMsg:
+		return m, con.RelayoutContainer(&m, m.size)
+	case tea.MouseMsg:
+		return m, (&m).handleMouseMsg(msg)
+	case con.ComponentsMsg:
+		return m, (&m).handleComponentsMsg(msg)
+	case con.SelectedMsg:
+		return m, con.HandleSelectedMsg(m.GetComponents(), msg)
+	}
+	for _, component := range m.GetComponents() {
+		cmds = append(cmds, component.Update(msg))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+/*
+Returns a number that changes whenever the grid's View would return something different: when
+it's laid out differently, or when one of its visible components would be rendered differently
+*/
+func (m GridContainerModel) GetRenderRevision() uint64 {
+	hasher := con.NewRenderHasher().AddSize(m.GetFullContainerSize())
+	focused := m.GetFocusHandler().GetFocusedComponent()
+	for _, component := range m.GetVisibleComponents() {
+		hasher.AddComponent(component, component == focused)
+	}
+	return hasher.AddFocusHandler(m.GetFocusHandler()).Sum()
+}
+
+/*
+Renders each visible component at the position of the cells it spans
+*/
+func (m GridContainerModel) View() string {
+	// untouched grids (and the subtrees inside of them) aren't placed together again
+	return m.viewCache.Get(m.GetRenderRevision(), m.view)
+}
+
+func (m GridContainerModel) view() string {
+	canvasSize := m.GetFullContainerSize()
+	if canvasSize.Width < 1 || canvasSize.Height < 1 {
+		return ""
+	}
+	output := strings.TrimSuffix(
+		strings.Repeat(strings.Repeat(utils.WHITESPACE_CHAR, canvasSize.Width)+"\n", canvasSize.Height),
+		"\n",
+	)
+	for _, component := range m.GetVisibleComponents() {
+		bounds := component.GetBounds()
+		output = utils.PlaceStacked(
+			output,
+			m.ViewComponent(component),
+			utils.TOP_LEFT,
+			bounds.Y,
+			bounds.X,
+		)
+	}
+	return con.PlaceFocusOverlay(output, m.GetFocusHandler())
+}
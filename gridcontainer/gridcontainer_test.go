@@ -0,0 +1,177 @@
+package gridcontainer
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	con "github.com/argotnaut/vanitea/container"
+)
+
+type sizedModel struct{}
+
+func (m sizedModel) Init() tea.Cmd                       { return nil }
+func (m sizedModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (m sizedModel) View() string                        { return "" }
+
+func newComponent() *con.Component {
+	return con.ComponentFromModel(sizedModel{}).SetBorderStyle(con.NO_BORDER_STYLE).SetFocusBorderStyle(con.NO_BORDER_STYLE)
+}
+
+func TestSolveTracks(t *testing.T) {
+	getStyle := func(*con.Component) lipgloss.Style { return con.NO_BORDER_STYLE }
+	cases := []struct {
+		name      string
+		tracks    []TrackSize
+		cells     []*GridCell
+		available int
+		want      []int
+	}{
+		{
+			name:      "fixed",
+			tracks:    []TrackSize{Fixed(3), Fixed(4)},
+			available: 10,
+			want:      []int{3, 4},
+		},
+		{
+			name:      "fractions by weight",
+			tracks:    []TrackSize{Fraction(1), Fraction(3)},
+			available: 12,
+			want:      []int{3, 9},
+		},
+		{
+			name:      "remainder goes to the earlier fractions",
+			tracks:    []TrackSize{Fraction(1), Fraction(1), Fraction(1)},
+			available: 10,
+			want:      []int{4, 3, 3},
+		},
+		{
+			name:      "fractions share what the fixed tracks leave",
+			tracks:    []TrackSize{Fixed(4), Fraction(1), Fraction(1)},
+			available: 10,
+			want:      []int{4, 3, 3},
+		},
+		{
+			name:      "auto track fits its component's maximum size",
+			tracks:    []TrackSize{Auto(), Fraction(1)},
+			cells:     []*GridCell{{Component: newComponent().SetMaximumWidth(4), RowSpan: 1, ColumnSpan: 1}},
+			available: 10,
+			want:      []int{4, 6},
+		},
+		{
+			name:      "auto track fits its component's minimum size if it has no maximum",
+			tracks:    []TrackSize{Auto(), Fraction(1)},
+			cells:     []*GridCell{{Component: newComponent().SetMinimumWidth(3), RowSpan: 1, ColumnSpan: 1}},
+			available: 10,
+			want:      []int{3, 7},
+		},
+		{
+			name:      "fraction kept at its component's minimum size",
+			tracks:    []TrackSize{Fraction(1), Fraction(3)},
+			cells:     []*GridCell{{Component: newComponent().SetMinimumWidth(5), RowSpan: 1, ColumnSpan: 1}},
+			available: 12,
+			want:      []int{5, 7},
+		},
+		{
+			name:      "spanning components don't size auto tracks",
+			tracks:    []TrackSize{Auto(), Fraction(1)},
+			cells:     []*GridCell{{Component: newComponent().SetMinimumWidth(6), RowSpan: 1, ColumnSpan: 2}},
+			available: 10,
+			want:      []int{0, 10},
+		},
+		{
+			name:      "tracks past the available space are truncated",
+			tracks:    []TrackSize{Fixed(6), Fixed(6), Fraction(1)},
+			available: 10,
+			want:      []int{6, 4, 0},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := solveTracks(c.tracks, c.available, c.cells, getStyle, true); !slices.Equal(got, c.want) {
+				t.Errorf("solveTracks(%v, %d) = %v, want %v", c.tracks, c.available, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSpans(t *testing.T) {
+	wide, tall, single := newComponent(), newComponent(), newComponent()
+	grid := NewGridContainer(
+		[]TrackSize{Fixed(2), Fixed(3), Fraction(1)},
+		[]TrackSize{Fixed(4), Fraction(1), Fraction(1)},
+	).
+		AddComponent(wide, 0, 0, 1, 3).
+		AddComponent(tall, 1, 0, 2, 1).
+		AddComponent(single, 1, 1, 1, 1)
+	grid.ResizeComponents(tea.WindowSizeMsg{Width: 14, Height: 10})
+	cases := []struct {
+		name      string
+		component *con.Component
+		want      con.Rectangle
+	}{
+		{name: "across every column", component: wide, want: con.Rectangle{X: 0, Y: 0, Width: 14, Height: 2}},
+		{name: "across two rows", component: tall, want: con.Rectangle{X: 0, Y: 2, Width: 4, Height: 8}},
+		{name: "single cell", component: single, want: con.Rectangle{X: 4, Y: 2, Width: 5, Height: 3}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.component.GetBounds(); got != c.want {
+				t.Errorf("bounds = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestAddComponent(t *testing.T) {
+	placed := newComponent()
+	cases := []struct {
+		name       string
+		component  *con.Component
+		row        int
+		column     int
+		wantPlaced bool
+	}{
+		{name: "inside of the grid", component: newComponent(), row: 1, column: 1, wantPlaced: true},
+		{name: "past the last row", component: newComponent(), row: 2, column: 0},
+		{name: "past the last column", component: newComponent(), row: 0, column: 2},
+		{name: "negative row", component: newComponent(), row: -1, column: 0},
+		{name: "nil component", component: nil, row: 0, column: 0},
+		{name: "already in the grid", component: placed, row: 1, column: 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			grid := NewGridContainer([]TrackSize{Fraction(1), Fraction(1)}, []TrackSize{Fraction(1), Fraction(1)}).
+				AddComponent(placed, 0, 0, 1, 1).
+				AddComponent(c.component, c.row, c.column, 1, 1)
+			wantCells := 1
+			if c.wantPlaced {
+				wantCells = 2
+			}
+			if got := len(grid.GetCells()); got != wantCells {
+				t.Fatalf("the grid has %d cells, want %d", got, wantCells)
+			}
+			if cell := grid.GetCell(placed); cell == nil || cell.Row != 0 || cell.Column != 0 {
+				t.Errorf("the component that was already placed moved to %v", cell)
+			}
+		})
+	}
+}
+
+func TestUnsupportedComponentsMsg(t *testing.T) {
+	grid := NewGridContainer([]TrackSize{Fraction(1)}, []TrackSize{Fraction(1)}).AddComponent(newComponent().SetID("a"), 0, 0, 1, 1)
+	for _, msg := range []con.ComponentsMsg{
+		con.InsertComponentMsg{Component: newComponent()},
+		con.MoveComponentMsg{ComponentID: "a"},
+	} {
+		cmd := grid.handleComponentsMsg(msg)
+		if cmd == nil {
+			t.Fatalf("handling a %T returned no tea.Cmd", msg)
+		}
+		if _, isError := cmd().(con.ErrorMsg); !isError {
+			t.Errorf("handling a %T didn't report an error", msg)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package gridcontainer
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+
+	con "github.com/argotnaut/vanitea/container"
+	"github.com/argotnaut/vanitea/utils"
+)
+
+const (
+	// The track is always the given number of cells
+	FIXED int = iota
+	// The track shares the space left over by the other tracks according to its weight
+	FRACTION
+	// The track is sized by the components placed in it
+	AUTO
+)
+
+/*
+Describes how the size of a single row or column of a GridContainerModel is determined
+*/
+type TrackSize struct {
+	// Whether the track is FIXED, FRACTION or AUTO
+	kind int
+	// The number of cells for FIXED tracks, or the weight for FRACTION tracks
+	value int
+}
+
+/*
+Returns a TrackSize that is always the given number of cells
+*/
+func Fixed(cells int) TrackSize {
+	return TrackSize{kind: FIXED, value: max(0, cells)}
+}
+
+/*
+Returns a TrackSize that shares the space left over by the FIXED and AUTO tracks
+with the other FRACTION tracks, in proportion to the given weight
+*/
+func Fraction(weight int) TrackSize {
+	return TrackSize{kind: FRACTION, value: max(0, weight)}
+}
+
+/*
+Returns a TrackSize that grows to the largest finite maximum size (or the minimum
+size, if the maximum is unbounded) of the components that span only that track
+*/
+func Auto() TrackSize {
+	return TrackSize{kind: AUTO}
+}
+
+/*
+Returns whether the track is FIXED, FRACTION or AUTO
+*/
+func (t TrackSize) GetKind() int {
+	return t.kind
+}
+
+/*
+Returns the number of cells for FIXED tracks, or the weight for FRACTION tracks
+*/
+func (t TrackSize) GetValue() int {
+	return t.value
+}
+
+/*
+Returns the size an AUTO track would need to be to fit the given component
+*/
+func autoSizeFor(minimum int, maximum int) int {
+	if maximum == math.MaxInt {
+		return minimum
+	}
+	return max(minimum, maximum)
+}
+
+/*
+Returns the visible cells that span only the given track
+*/
+func getSingleTrackCells(cells []*GridCell, track int, isColumn bool) (output []*GridCell) {
+	for _, cell := range cells {
+		start, span := cell.Row, cell.RowSpan
+		if isColumn {
+			start, span = cell.Column, cell.ColumnSpan
+		}
+		if start == track && span == 1 && !cell.Component.IsHidden() {
+			output = append(output, cell)
+		}
+	}
+	return output
+}
+
+/*
+Returns the minimum and maximum width (for columns) or height (for rows) of the cell's component
+when it's rendered with the style the given function returns for it (see con.Component.GetLayoutBounds)
+*/
+func getCellBounds(cell *GridCell, getStyle func(*con.Component) lipgloss.Style, isColumn bool) (minimum int, maximum int) {
+	minimumSize, maximumSize := cell.Component.GetLayoutBounds(getStyle(cell.Component))
+	if isColumn {
+		return minimumSize.Width, maximumSize.Width
+	}
+	return minimumSize.Height, maximumSize.Height
+}
+
+/*
+Returns the largest minimum size of the components that span only the given track (see getCellBounds),
+which is the smallest size a FRACTION track can be without crushing them
+*/
+func getFractionMinimum(cells []*GridCell, track int, getStyle func(*con.Component) lipgloss.Style, isColumn bool) (minimum int) {
+	for _, cell := range getSingleTrackCells(cells, track, isColumn) {
+		cellMinimum, _ := getCellBounds(cell, getStyle, isColumn)
+		minimum = max(minimum, cellMinimum)
+	}
+	return minimum
+}
+
+/*
+Returns the smallest and largest amounts of space the given tracks can take up: FIXED tracks are always
+their own size, AUTO tracks are the size they grow to, and FRACTION tracks need at least as much space as the
+largest minimum size of the components that span only that track (and can use any amount of space). The
+components that span several tracks aren't taken into account
+*/
+func getTrackBounds(tracks []TrackSize, cells []*GridCell, getStyle func(*con.Component) lipgloss.Style, isColumn bool) (minimum int, maximum int) {
+	for i, track := range tracks {
+		size := 0
+		switch track.kind {
+		case FIXED:
+			size = track.value
+		case AUTO:
+			for _, cell := range getSingleTrackCells(cells, i, isColumn) {
+				size = max(size, autoSizeFor(getCellBounds(cell, getStyle, isColumn)))
+			}
+		case FRACTION:
+			size = getFractionMinimum(cells, i, getStyle, isColumn)
+			maximum = math.MaxInt
+		}
+		minimum = utils.SaturatingSum(minimum, size)
+		maximum = utils.SaturatingSum(maximum, size)
+	}
+	return minimum, maximum
+}
+
+/*
+Given the tracks along one axis of the grid and the space available along that axis,
+returns the size of each track. FIXED and AUTO tracks are sized first, and the space that
+remains is split between the FRACTION tracks by weight, with any cells left over from the
+integer division going to the earliest FRACTION tracks one at a time. A FRACTION track whose
+share would be smaller than the minimum size of a component that spans only that track is
+kept at that size instead, and the rest of the space is split between the other FRACTION
+tracks. Tracks that don't fit in the available space are truncated
+
+tracks: []TrackSize - The sizing rules for each track
+available: int - The number of cells available along the axis
+cells: []*GridCell - The cells placed in the grid
+getStyle: func(*con.Component) lipgloss.Style - Returns the style each component is rendered with
+isColumn: bool - Whether the tracks are columns (as opposed to rows)
+*/
+func solveTracks(tracks []TrackSize, available int, cells []*GridCell, getStyle func(*con.Component) lipgloss.Style, isColumn bool) []int {
+	sizes := make([]int, len(tracks))
+	remaining := available
+	// whether each FRACTION track still shares the remaining space (rather than being kept at its minimum size)
+	sharing := make([]bool, len(tracks))
+	totalWeight := 0
+	for i, track := range tracks {
+		switch track.kind {
+		case FIXED:
+			sizes[i] = track.value
+		case AUTO:
+			for _, cell := range getSingleTrackCells(cells, i, isColumn) {
+				minimum, maximum := getCellBounds(cell, getStyle, isColumn)
+				sizes[i] = max(sizes[i], autoSizeFor(minimum, maximum))
+			}
+		case FRACTION:
+			sizes[i] = getFractionMinimum(cells, i, getStyle, isColumn)
+			if track.value > 0 {
+				sharing[i] = true
+				totalWeight += track.value
+				continue
+			}
+		}
+		remaining -= sizes[i]
+	}
+
+	// keep the tracks whose shares would be too small at their minimum sizes until every remaining share fits
+	for changed := true; changed; {
+		changed = false
+		for i, track := range tracks {
+			if sharing[i] && max(0, remaining)*track.value/totalWeight < sizes[i] {
+				sharing[i] = false
+				totalWeight -= track.value
+				remaining -= sizes[i]
+				changed = true
+			}
+		}
+	}
+
+	if totalWeight > 0 && remaining > 0 {
+		distributed := 0
+		for i, track := range tracks {
+			if sharing[i] {
+				sizes[i] = remaining * track.value / totalWeight
+				distributed += sizes[i]
+			}
+		}
+		for i := range tracks {
+			if distributed >= remaining {
+				break
+			}
+			if sharing[i] {
+				sizes[i]++
+				distributed++
+			}
+		}
+	}
+
+	// truncate any tracks that extend beyond the available space
+	offset := 0
+	for i := range sizes {
+		sizes[i] = max(0, min(sizes[i], available-offset))
+		offset += sizes[i]
+	}
+	return sizes
+}
+
+/*
+Returns the sum of the track sizes in the range [start, start+span), along with
+the sum of the track sizes before start
+*/
+func spanOf(sizes []int, start int, span int) (offset int, size int) {
+	for i := range sizes {
+		if i < start {
+			offset += sizes[i]
+		} else if i < start+span {
+			size += sizes[i]
+		}
+	}
+	return
+}
+
+/*
+Returns the rectangle a cell occupies, given the sizes of the grid's rows and columns
+*/
+func cellBounds(cell *GridCell, rowSizes []int, columnSizes []int) con.Rectangle {
+	x, width := spanOf(columnSizes, cell.Column, cell.ColumnSpan)
+	y, height := spanOf(rowSizes, cell.Row, cell.RowSpan)
+	return con.Rectangle{X: x, Y: y, Width: width, Height: height}
+}
@@ -7,6 +7,7 @@ import (
 	"image/draw"
 	"image/gif"
 	"log"
+	"math"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -237,6 +238,55 @@ func getImageDimensions(image image.Image) tea.WindowSizeMsg {
 	}
 }
 
+/*
+Returns the dimensions imaging.Fit gives an image with the given dimensions when fitting it within
+the given bounds (it scales the image down to fit while preserving its aspect ratio, but never scales it up)
+*/
+func getFittedDimensions(imageDimensions tea.WindowSizeMsg, bounds tea.WindowSizeMsg) (output tea.WindowSizeMsg) {
+	if bounds.Width <= 0 || bounds.Height <= 0 || imageDimensions.Width <= 0 || imageDimensions.Height <= 0 {
+		return output
+	}
+	if imageDimensions.Width <= bounds.Width && imageDimensions.Height <= bounds.Height {
+		return imageDimensions
+	}
+	imageAspectRatio := float64(imageDimensions.Width) / float64(imageDimensions.Height)
+	if imageAspectRatio > float64(bounds.Width)/float64(bounds.Height) {
+		output.Width = bounds.Width
+		output.Height = int(float64(output.Width) / imageAspectRatio)
+	} else {
+		output.Height = bounds.Height
+		output.Width = int(float64(output.Height) * imageAspectRatio)
+	}
+	// imaging.Resize preserves the aspect ratio when one of the dimensions is 0 (keeping at least 1 pixel)
+	if output.Width == 0 {
+		output.Width = int(math.Max(1, math.Floor(float64(output.Height)*imageAspectRatio+0.5)))
+	}
+	if output.Height == 0 {
+		output.Height = int(math.Max(1, math.Floor(float64(output.Width)/imageAspectRatio+0.5)))
+	}
+	return output
+}
+
+/*
+Returns the number of characters (columns and rows) that getScaledImage's rendering
+of the given image within the given size takes up, without rendering it
+*/
+func getScaledImageDimensions(frames []image.Image, size tea.WindowSizeMsg) tea.WindowSizeMsg {
+	if len(frames) < 1 {
+		return tea.WindowSizeMsg{Width: 3, Height: 1} // the size of getScaledImage's error output
+	}
+	size.Height *= 2 // multiply height by two to convert from characters to "pixels"
+	pixels := getFittedDimensions(
+		getImageDimensions(frames[0]),
+		rescaleImageToBounds(getImageDimensions(frames[0]), size),
+	)
+	// each row of characters shows two rows of pixels (an odd last row of pixels isn't shown)
+	if pixels.Height < 2 {
+		return tea.WindowSizeMsg{}
+	}
+	return tea.WindowSizeMsg{Width: pixels.Width, Height: pixels.Height / 2}
+}
+
 /*
 Given an image and a target size, this function returns the image as
 an ANSI-escaped ASCII pixel string
@@ -4,6 +4,8 @@ import (
 	"image"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	con "github.com/argotnaut/vanitea/container"
 )
 
 const (
@@ -18,6 +20,7 @@ type ImageViewModel struct {
 	imageFrames       []image.Image     // The original image bytes' decoded color values
 	stringifiedImage  string            // The current ASCII representation of the image
 	currentDimensions tea.WindowSizeMsg // The dimensions within which to display the image
+	renderRevision    uint64            // Changes whenever stringifiedImage does (see con.RenderRevisioner)
 }
 
 /*
@@ -26,6 +29,7 @@ Returns a new ImageViewModel with the given image bytes
 func NewImageViewModelFromBytes(imageBytes []byte) (output ImageViewModel) {
 	imageFrames, _ := decodeImageBytes(imageBytes)
 	output.imageFrames = imageFrames
+	output.renderRevision = con.NewRenderRevision()
 	output.RerenderImage(output.currentDimensions)
 	return
 }
@@ -39,6 +43,7 @@ func (m *ImageViewModel) RerenderImage(newDimensions tea.WindowSizeMsg) *ImageVi
 	heightHasChanged := m.currentDimensions.Height != newDimensions.Height
 	if widthHasChanged || heightHasChanged {
 		m.stringifiedImage = getScaledImage(m.imageFrames, &newDimensions)
+		m.renderRevision = con.NewRenderRevision()
 	}
 	return m
 }
@@ -56,6 +61,32 @@ func (m ImageViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+/*
+Returns a number that changes whenever the image is rendered again, so that the
+image's Component only renders it again when it has changed
+*/
+func (m ImageViewModel) GetRenderRevision() uint64 {
+	return m.renderRevision
+}
+
+/*
+Returns the size of the image when it's rendered within the given constraint. The image can be
+shown at any size down to nothing, but is never scaled up beyond its original size (which only
+limits the size of the image's Component if it shrinks to its content, see con.GetLayoutBounds)
+*/
+func (m ImageViewModel) GetContentSize(constraint tea.WindowSizeMsg) con.ContentSize {
+	preferred := getScaledImageDimensions(m.imageFrames, constraint)
+	if len(m.imageFrames) < 1 {
+		// the error output is the same size at every size
+		return con.ContentSize{Preferred: preferred, Minimum: preferred, Maximum: preferred}
+	}
+	original := getImageDimensions(m.imageFrames[0])
+	return con.ContentSize{
+		Preferred: preferred,
+		Maximum:   tea.WindowSizeMsg{Width: original.Width, Height: original.Height / 2},
+	}
+}
+
 func (m ImageViewModel) View() string {
 	return m.stringifiedImage
 }
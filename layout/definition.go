@@ -0,0 +1,381 @@
+package layout
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	con "github.com/argotnaut/vanitea/container"
+	gc "github.com/argotnaut/vanitea/gridcontainer"
+	lc "github.com/argotnaut/vanitea/linearcontainer"
+)
+
+const (
+	LINEAR_CONTAINER = "linear"
+	TAB_CONTAINER    = "tabs"
+	GRID_CONTAINER   = "grid"
+)
+
+// The directions a linear container node can have
+var DIRECTION_NAMES = map[string]int{
+	"horizontal": lc.HORIZONTAL,
+	"vertical":   lc.VERTICAL,
+	"stack":      lc.STACK,
+}
+
+// The border styles a node can have (containers have no border unless they ask for one)
+var BORDER_NAMES = map[string]lipgloss.Border{
+	"rounded": lipgloss.RoundedBorder(),
+	"normal":  lipgloss.NormalBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"hidden":  lipgloss.HiddenBorder(),
+	"none":    NO_BORDER,
+}
+
+// The border that isn't rendered at all
+var NO_BORDER = con.NO_BORDER_STYLE.GetBorderStyle()
+
+/*
+A declarative description of a layout: a tree of nodes, each of which is either a
+model (created by a ModelFactory from a Registry) or a container of other nodes
+*/
+type Definition struct {
+	// The name of the application (shown in the AppFrame's breadcrumb)
+	Name string `json:"name"`
+	// The node at the root of the layout
+	Root NodeDefinition `json:"root"`
+}
+
+/*
+A single node of a layout Definition. A node sets either Model or Container
+*/
+type NodeDefinition struct {
+	// An identifier for the node's component, unique within the layout
+	ID string `json:"id,omitempty"`
+	// Labels that selectors can match the node's component by (see con.Query)
+	Tags []string `json:"tags,omitempty"`
+	// The name the node's ModelFactory is registered under
+	Model string `json:"model,omitempty"`
+	// The options passed to the node's ModelFactory
+	Options json.RawMessage `json:"options,omitempty"`
+	// The kind of container (LINEAR_CONTAINER, TAB_CONTAINER or GRID_CONTAINER)
+	Container string `json:"container,omitempty"`
+	// The direction of a linear container (one of the names in DIRECTION_NAMES)
+	Direction string `json:"direction,omitempty"`
+	// The number of empty cells between the components of a linear container
+	Gap int `json:"gap,omitempty"`
+	// The sizes of the rows and columns of a grid container ("auto", "<cells>" or "<weight>fr")
+	Rows    []string `json:"rows,omitempty"`
+	Columns []string `json:"columns,omitempty"`
+	// The placement of the node in its parent grid container
+	Row        int `json:"row,omitempty"`
+	Column     int `json:"column,omitempty"`
+	RowSpan    int `json:"rowSpan,omitempty"`
+	ColumnSpan int `json:"columnSpan,omitempty"`
+
+	Title        string `json:"title,omitempty"`
+	ShowTitle    bool   `json:"showTitle,omitempty"`
+	Shortcut     string `json:"shortcut,omitempty"`
+	ShowShortcut bool   `json:"showShortcut,omitempty"`
+	// The style of the node's border (one of the names in BORDER_NAMES)
+	Border string `json:"border,omitempty"`
+	// The size the node asks for along its container's major axis ("auto", "<cells>", "<percent>%" or "<weight>fr")
+	Size          string `json:"size,omitempty"`
+	Priority      *int   `json:"priority,omitempty"`
+	MinimumWidth  *int   `json:"minimumWidth,omitempty"`
+	MaximumWidth  *int   `json:"maximumWidth,omitempty"`
+	MinimumHeight *int   `json:"minimumHeight,omitempty"`
+	MaximumHeight *int   `json:"maximumHeight,omitempty"`
+	Focusable     *bool  `json:"focusable,omitempty"`
+	Hidden        bool   `json:"hidden,omitempty"`
+	Collapsed     bool   `json:"collapsed,omitempty"`
+	// Whether focus is kept inside the node while it's visible (see con.Component.SetTrapsFocus)
+	TrapFocus bool `json:"trapFocus,omitempty"`
+
+	// The nodes inside of a container node
+	Children []NodeDefinition `json:"children,omitempty"`
+}
+
+/*
+An error in a layout Definition, along with the path of the node it was found in
+(e.g. root.children[2].children[0])
+*/
+type DefinitionError struct {
+	Path    string
+	Message string
+}
+
+func (e DefinitionError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+/*
+Returns the given error with the given path segment put in front of its path (errors
+that aren't DefinitionErrors become DefinitionErrors at the given path)
+*/
+func prependPath(err error, segment string) error {
+	var definitionErr DefinitionError
+	if !errors.As(err, &definitionErr) {
+		return DefinitionError{Path: segment, Message: err.Error()}
+	}
+	if definitionErr.Path != "" {
+		segment += "."
+	}
+	definitionErr.Path = segment + definitionErr.Path
+	return definitionErr
+}
+
+/*
+Decodes the node strictly (unknown fields are errors), decoding each child separately
+so that errors in the children can be traced back to them
+*/
+func (n *NodeDefinition) UnmarshalJSON(data []byte) error {
+	// an alias without the UnmarshalJSON method, so that the fields can be decoded normally
+	type fields NodeDefinition
+	var raw struct {
+		fields
+		Children []json.RawMessage `json:"children,omitempty"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&raw); err != nil {
+		return DefinitionError{Message: strings.TrimPrefix(err.Error(), "json: ")}
+	}
+	*n = NodeDefinition(raw.fields)
+	n.Children = nil
+	for idx, rawChild := range raw.Children {
+		var child NodeDefinition
+		if err := child.UnmarshalJSON(rawChild); err != nil {
+			return prependPath(err, fmt.Sprintf("children[%d]", idx))
+		}
+		n.Children = append(n.Children, child)
+	}
+	return nil
+}
+
+/*
+Parses the given JSON layout Definition. Errors in the nodes are DefinitionErrors with
+the path of the node they were found in
+*/
+func ParseDefinition(data []byte) (output Definition, err error) {
+	var raw struct {
+		Name string          `json:"name"`
+		Root json.RawMessage `json:"root"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&raw); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, column := getLineAndColumn(data, syntaxErr.Offset)
+			return output, fmt.Errorf("invalid layout definition at line %d, column %d: %w", line, column, err)
+		}
+		return output, fmt.Errorf("invalid layout definition: %w", err)
+	}
+	if len(raw.Root) == 0 {
+		return output, DefinitionError{Path: "root", Message: "the layout definition has no root node"}
+	}
+	output.Name = raw.Name
+	if err := output.Root.UnmarshalJSON(raw.Root); err != nil {
+		return output, prependPath(err, "root")
+	}
+	return output, nil
+}
+
+/*
+Returns the line and column (both starting from 1) of the given byte offset in the given data
+*/
+func getLineAndColumn(data []byte, offset int64) (line int, column int) {
+	before := data[:min(int64(len(data)), max(0, offset))]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = len(before) - bytes.LastIndexByte(before, '\n')
+	return
+}
+
+/*
+Checks the Definition for errors that can be found without building it (using the given
+Registry to check the names of the models), returning all of them joined together
+*/
+func (d Definition) Validate(registry *Registry) error {
+	ids, shortcuts := map[string]string{}, map[string]string{}
+	return errors.Join(d.Root.validate("root", registry, ids, shortcuts, "")...)
+}
+
+/*
+Checks the node and its children for errors. ids and shortcuts hold the path of the node
+each ID and shortcut was first seen in, and parentContainer is the kind of container the
+node is in
+*/
+func (n NodeDefinition) validate(
+	path string,
+	registry *Registry,
+	ids map[string]string,
+	shortcuts map[string]string,
+	parentContainer string,
+) (errs []error) {
+	fail := func(format string, args ...any) {
+		errs = append(errs, DefinitionError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n.ID != "" && !con.IsSelectorName(n.ID) {
+		fail("the ID %q can only have letters, digits, hyphens and underscores", n.ID)
+	}
+	for _, tag := range n.Tags {
+		if !con.IsSelectorName(tag) {
+			fail("the tag %q can only have letters, digits, hyphens and underscores", tag)
+		}
+	}
+	if n.ID != "" {
+		if firstPath, exists := ids[n.ID]; exists {
+			fail("the ID %q is already used by %s", n.ID, firstPath)
+		} else {
+			ids[n.ID] = path
+		}
+	}
+	if n.Shortcut != "" {
+		if firstPath, exists := shortcuts[n.Shortcut]; exists {
+			fail("the shortcut %q is already used by %s", n.Shortcut, firstPath)
+		} else {
+			shortcuts[n.Shortcut] = path
+		}
+	}
+
+	switch {
+	case n.Model == "" && n.Container == "":
+		fail("a node needs either a \"model\" or a \"container\"")
+	case n.Model != "" && n.Container != "":
+		fail("a node can't have both a \"model\" (%q) and a \"container\" (%q)", n.Model, n.Container)
+	case n.Model != "":
+		if _, ok := registry.GetFactory(n.Model); !ok {
+			fail("unknown model %q (registered models: %s)", n.Model, strings.Join(registry.GetNames(), ", "))
+		}
+		if len(n.Children) > 0 {
+			fail("the model %q can't have children", n.Model)
+		}
+	case n.Container != LINEAR_CONTAINER && n.Container != TAB_CONTAINER && n.Container != GRID_CONTAINER:
+		fail("unknown container %q (expected %q, %q or %q)", n.Container, LINEAR_CONTAINER, TAB_CONTAINER, GRID_CONTAINER)
+	}
+
+	if _, ok := DIRECTION_NAMES[n.Direction]; n.Direction != "" && !ok {
+		fail("unknown direction %q (expected \"horizontal\", \"vertical\" or \"stack\")", n.Direction)
+	}
+	if n.Direction != "" && n.Container != LINEAR_CONTAINER {
+		fail("only %q containers have a direction", LINEAR_CONTAINER)
+	}
+	if _, ok := BORDER_NAMES[n.Border]; n.Border != "" && !ok {
+		fail("unknown border %q", n.Border)
+	}
+	if _, err := parseSizeHint(n.Size); err != nil {
+		fail("invalid size: %s", err)
+	}
+	if n.MinimumWidth != nil && n.MaximumWidth != nil && *n.MinimumWidth > *n.MaximumWidth {
+		fail("minimumWidth (%d) is larger than maximumWidth (%d)", *n.MinimumWidth, *n.MaximumWidth)
+	}
+	if n.MinimumHeight != nil && n.MaximumHeight != nil && *n.MinimumHeight > *n.MaximumHeight {
+		fail("minimumHeight (%d) is larger than maximumHeight (%d)", *n.MinimumHeight, *n.MaximumHeight)
+	}
+
+	if n.Container == GRID_CONTAINER {
+		if len(n.Rows) < 1 || len(n.Columns) < 1 {
+			fail("a %q container needs at least one row and one column", GRID_CONTAINER)
+		}
+		for idx, track := range n.Rows {
+			if _, err := parseTrackSize(track); err != nil {
+				fail("invalid rows[%d]: %s", idx, err)
+			}
+		}
+		for idx, track := range n.Columns {
+			if _, err := parseTrackSize(track); err != nil {
+				fail("invalid columns[%d]: %s", idx, err)
+			}
+		}
+	} else if len(n.Rows) > 0 || len(n.Columns) > 0 {
+		fail("only %q containers have rows and columns", GRID_CONTAINER)
+	}
+	if parentContainer != GRID_CONTAINER && (n.Row != 0 || n.Column != 0 || n.RowSpan != 0 || n.ColumnSpan != 0) {
+		fail("only the children of %q containers have a row and a column", GRID_CONTAINER)
+	}
+
+	for idx, child := range n.Children {
+		childPath := fmt.Sprintf("%s.children[%d]", path, idx)
+		errs = append(errs, child.validate(childPath, registry, ids, shortcuts, n.Container)...)
+		if n.Container == GRID_CONTAINER {
+			if child.Row < 0 || child.Row >= len(n.Rows) || child.Column < 0 || child.Column >= len(n.Columns) {
+				errs = append(errs, DefinitionError{
+					Path:    childPath,
+					Message: fmt.Sprintf("the cell (%d, %d) is outside of the %dx%d grid", child.Row, child.Column, len(n.Rows), len(n.Columns)),
+				})
+			}
+		}
+	}
+	return errs
+}
+
+/*
+Parses a size hint ("auto", "<cells>", "<percent>%" or "<weight>fr"). An empty string is "auto"
+*/
+func parseSizeHint(input string) (con.SizeHint, error) {
+	input = strings.TrimSpace(input)
+	switch {
+	case input == "" || input == "auto":
+		return con.AutoSize(), nil
+	case strings.HasSuffix(input, "%"):
+		value, err := parseCount(strings.TrimSuffix(input, "%"))
+		return con.PercentSize(value), err
+	case strings.HasSuffix(input, "fr"):
+		value, err := parseCount(strings.TrimSuffix(input, "fr"))
+		return con.WeightedSize(value), err
+	}
+	value, err := parseCount(input)
+	return con.FixedSize(value), err
+}
+
+/*
+Parses the size of a grid track ("auto", "<cells>" or "<weight>fr")
+*/
+func parseTrackSize(input string) (gc.TrackSize, error) {
+	input = strings.TrimSpace(input)
+	switch {
+	case input == "auto":
+		return gc.Auto(), nil
+	case strings.HasSuffix(input, "fr"):
+		value, err := parseCount(strings.TrimSuffix(input, "fr"))
+		return gc.Fraction(value), err
+	}
+	value, err := parseCount(input)
+	return gc.Fixed(value), err
+}
+
+/*
+Returns the given gc.TrackSize in the notation parsed by parseTrackSize
+*/
+func formatTrackSize(track gc.TrackSize) string {
+	switch track.GetKind() {
+	case gc.AUTO:
+		return "auto"
+	case gc.FRACTION:
+		return strconv.Itoa(track.GetValue()) + "fr"
+	}
+	return strconv.Itoa(track.GetValue())
+}
+
+/*
+Parses a non-negative whole number
+*/
+func parseCount(input string) (int, error) {
+	value, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || value < 0 || value > math.MaxInt32 {
+		return 0, fmt.Errorf("%q isn't a whole number of zero or more", input)
+	}
+	return value, nil
+}
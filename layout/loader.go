@@ -0,0 +1,165 @@
+package layout
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	af "github.com/argotnaut/vanitea/appframe"
+	con "github.com/argotnaut/vanitea/container"
+	gc "github.com/argotnaut/vanitea/gridcontainer"
+	lc "github.com/argotnaut/vanitea/linearcontainer"
+	tc "github.com/argotnaut/vanitea/tabcontainer"
+)
+
+/*
+Reads the layout Definition in the JSON file at the given path and builds it into an
+AppFrame, creating the models of the layout with the factories in the given Registry
+*/
+func LoadAppFrame(path string, registry *Registry) (af.AppFrame, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return af.AppFrame{}, fmt.Errorf("couldn't read the layout definition: %w", err)
+	}
+	definition, err := ParseDefinition(data)
+	if err != nil {
+		return af.AppFrame{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return definition.BuildAppFrame(registry)
+}
+
+/*
+Builds the Definition into an AppFrame whose only component is the root node (so the
+root keeps its own properties, like its gap, ID, tags and whether it traps focus)
+*/
+func (d Definition) BuildAppFrame(registry *Registry) (af.AppFrame, error) {
+	root, err := d.Build(registry)
+	if err != nil {
+		return af.AppFrame{}, err
+	}
+	return af.NewAppFrame(d.Name, []*con.Component{root}), nil
+}
+
+/*
+Validates the Definition and builds it into a tree of components, returning the
+component of the root node
+*/
+func (d Definition) Build(registry *Registry) (*con.Component, error) {
+	if err := d.Validate(registry); err != nil {
+		return nil, err
+	}
+	return d.Root.build("root", registry)
+}
+
+/*
+Builds the node (and its children) into a Component
+*/
+func (n NodeDefinition) build(path string, registry *Registry) (*con.Component, error) {
+	var model tea.Model
+	if n.Model != "" {
+		factory, _ := registry.GetFactory(n.Model)
+		var err error
+		if model, err = factory(n.Options); err != nil {
+			return nil, DefinitionError{Path: path, Message: fmt.Sprintf("couldn't create the model %q: %s", n.Model, err)}
+		}
+		if model == nil {
+			return nil, DefinitionError{Path: path, Message: fmt.Sprintf("the factory for the model %q returned no model", n.Model)}
+		}
+	} else {
+		children := make([]*con.Component, 0, len(n.Children))
+		for idx, childNode := range n.Children {
+			child, err := childNode.build(fmt.Sprintf("%s.children[%d]", path, idx), registry)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		model = n.buildContainer(children)
+	}
+	return n.applyTo(con.ComponentFromModel(model)), nil
+}
+
+/*
+Returns the container model described by the node, holding the given children
+*/
+func (n NodeDefinition) buildContainer(children []*con.Component) tea.Model {
+	switch n.Container {
+	case TAB_CONTAINER:
+		return *tc.NewTabContainerFromComponents(children)
+	case GRID_CONTAINER:
+		rows, columns := make([]gc.TrackSize, len(n.Rows)), make([]gc.TrackSize, len(n.Columns))
+		for idx, track := range n.Rows {
+			rows[idx], _ = parseTrackSize(track)
+		}
+		for idx, track := range n.Columns {
+			columns[idx], _ = parseTrackSize(track)
+		}
+		grid := gc.NewGridContainer(rows, columns)
+		for idx, child := range children {
+			childNode := n.Children[idx]
+			grid.AddComponent(child, childNode.Row, childNode.Column, childNode.RowSpan, childNode.ColumnSpan)
+		}
+		return *grid
+	}
+	container := lc.NewLinearContainerFromComponents(children)
+	container.SetDirection(DIRECTION_NAMES[n.Direction]).SetGap(n.Gap)
+	return *container
+}
+
+/*
+Applies the node's properties to the given Component. Container nodes have no border
+and can't be focused themselves unless they ask to be
+*/
+func (n NodeDefinition) applyTo(component *con.Component) *con.Component {
+	component.SetID(n.ID).
+		SetTags(n.Tags...).
+		SetTitle(n.Title).
+		SetShowTitle(n.ShowTitle).
+		SetShortcut(n.Shortcut).
+		SetShowShortcut(n.ShowShortcut).
+		SetHidden(n.Hidden).
+		SetCollapsed(n.Collapsed).
+		SetTrapsFocus(n.TrapFocus)
+	hint, _ := parseSizeHint(n.Size)
+	component.SetSizeHint(hint)
+
+	border := n.Border
+	focusable := n.Focusable
+	if n.Container != "" {
+		if border == "" {
+			border = "none"
+		}
+		if focusable == nil {
+			focusable = new(bool)
+		}
+	}
+	if border == "none" {
+		component.SetBorderStyle(con.NO_BORDER_STYLE).
+			SetFocusBorderStyle(con.NO_BORDER_STYLE).
+			SetContainsFocusBorderStyle(con.NO_BORDER_STYLE)
+	} else if border != "" {
+		component.SetBorderStyle(con.BORDER_STYLE.BorderStyle(BORDER_NAMES[border]))
+		component.SetFocusBorderStyle(con.FOCUSED_BORDER_STYLE.BorderStyle(BORDER_NAMES[border]))
+		component.SetContainsFocusBorderStyle(con.CONTAINS_FOCUS_BORDER_STYLE.BorderStyle(BORDER_NAMES[border]))
+	}
+	if focusable != nil {
+		component.SetFocusable(*focusable)
+	}
+	if n.Priority != nil {
+		component.SetPriority(*n.Priority)
+	}
+	if n.MinimumWidth != nil {
+		component.SetMinimumWidth(*n.MinimumWidth)
+	}
+	if n.MaximumWidth != nil {
+		component.SetMaximumWidth(*n.MaximumWidth)
+	}
+	if n.MinimumHeight != nil {
+		component.SetMinimumHeight(*n.MinimumHeight)
+	}
+	if n.MaximumHeight != nil {
+		component.SetMaximumHeight(*n.MaximumHeight)
+	}
+	return component
+}
@@ -0,0 +1,57 @@
+package layout
+
+import (
+	"encoding/json"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/*
+Creates a tea.Model for a node of a layout definition. The options are the node's
+"options" value, left as raw JSON so that each factory can decode its own options
+(they're nil if the node doesn't have any)
+*/
+type ModelFactory func(options json.RawMessage) (tea.Model, error)
+
+/*
+The named ModelFactories that the nodes of a layout definition can refer to
+*/
+type Registry struct {
+	factories map[string]ModelFactory
+}
+
+/*
+Instantiates an empty Registry
+*/
+func NewRegistry() *Registry {
+	return &Registry{factories: map[string]ModelFactory{}}
+}
+
+/*
+Makes the given ModelFactory available to layout definitions under the given name
+(replacing any factory already registered under that name)
+*/
+func (r *Registry) Register(name string, factory ModelFactory) *Registry {
+	r.factories[name] = factory
+	return r
+}
+
+/*
+Returns the ModelFactory registered under the given name, if there is one
+*/
+func (r Registry) GetFactory(name string) (factory ModelFactory, ok bool) {
+	factory, ok = r.factories[name]
+	return
+}
+
+/*
+Returns the names of all of the registered ModelFactories, in alphabetical order
+*/
+func (r Registry) GetNames() (output []string) {
+	for name := range r.factories {
+		output = append(output, name)
+	}
+	slices.Sort(output)
+	return
+}
@@ -0,0 +1,465 @@
+package layout
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	con "github.com/argotnaut/vanitea/container"
+	gc "github.com/argotnaut/vanitea/gridcontainer"
+	lc "github.com/argotnaut/vanitea/linearcontainer"
+	tc "github.com/argotnaut/vanitea/tabcontainer"
+)
+
+// The version of the layout state document written by SaveLayout
+const LAYOUT_STATE_VERSION = 1
+
+// The names used for the kinds of size hints in layout state documents
+var SIZE_HINT_KIND_NAMES = map[int]string{
+	con.AUTO_SIZE:     "auto",
+	con.FIXED_SIZE:    "fixed",
+	con.PERCENT_SIZE:  "percent",
+	con.WEIGHTED_SIZE: "weighted",
+}
+
+/*
+The user-adjustable state of a tree of components, which can be saved as JSON and
+applied back onto a live tree of components later. Components are keyed by their IDs
+(components without an ID aren't recorded)
+*/
+type LayoutState struct {
+	// The version of the document's format
+	Version int `json:"version"`
+	// The ID of the focused component (the root container's, see ContainerState.Focused)
+	Focused string `json:"focused,omitempty"`
+	// The state of the root container
+	Root ContainerState `json:"root"`
+	// The state of each component, keyed by the component's ID
+	Components map[string]ComponentState `json:"components"`
+}
+
+/*
+The saved state of a single Component
+*/
+type ComponentState struct {
+	Priority      int           `json:"priority"`
+	MinimumWidth  int           `json:"minimumWidth"`
+	MaximumWidth  int           `json:"maximumWidth"`
+	MinimumHeight int           `json:"minimumHeight"`
+	MaximumHeight int           `json:"maximumHeight"`
+	SizeHint      SizeHintState `json:"sizeHint"`
+	Hidden        bool          `json:"hidden"`
+	Collapsed     bool          `json:"collapsed"`
+	ShowTitle     bool          `json:"showTitle"`
+	ShowShortcut  bool          `json:"showShortcut"`
+	// The state of the Component's model, if the model is a container
+	Container *ContainerState `json:"container,omitempty"`
+}
+
+/*
+The saved form of a con.SizeHint
+*/
+type SizeHintState struct {
+	// One of the names in SIZE_HINT_KIND_NAMES
+	Kind  string `json:"kind"`
+	Value int    `json:"value,omitempty"`
+}
+
+/*
+The saved state of a container
+*/
+type ContainerState struct {
+	// The direction of a LinearContainerModel (one of the names in DIRECTION_NAMES)
+	Direction string `json:"direction,omitempty"`
+	// The IDs of the container's components, in order
+	Order []string `json:"order,omitempty"`
+	// The ID of the active tab of a TabContainerModel
+	ActiveTab string `json:"activeTab,omitempty"`
+	// The ID of the component the container's FocusHandler focuses
+	Focused string `json:"focused,omitempty"`
+	// The sizes of the rows and columns of a GridContainerModel (in the notation of NodeDefinition.Rows)
+	Rows    []string `json:"rows,omitempty"`
+	Columns []string `json:"columns,omitempty"`
+	// The placement of each of the components of a GridContainerModel, keyed by the component's ID
+	Cells map[string]GridCellState `json:"cells,omitempty"`
+}
+
+/*
+The saved placement of a component within a GridContainerModel (see gc.GridCell)
+*/
+type GridCellState struct {
+	Row        int `json:"row"`
+	Column     int `json:"column"`
+	RowSpan    int `json:"rowSpan"`
+	ColumnSpan int `json:"columnSpan"`
+}
+
+/*
+Returns the JSON document describing the state of the given container and all of the
+components nested inside of it
+*/
+func SaveLayout(root con.Container) ([]byte, error) {
+	state, err := GetLayoutState(root)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(state, "", "  ")
+}
+
+/*
+Applies the state in the given JSON document (written by SaveLayout) onto the given
+container and the components nested inside of it. IDs in the document that don't match
+any of the components are ignored. The restored sizes take effect the next time the
+tree is laid out (e.g. on the next tea.WindowSizeMsg)
+*/
+func RestoreLayout(root con.Container, data []byte) error {
+	var state LayoutState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("couldn't parse the layout state: %w", err)
+	}
+	return ApplyLayoutState(root, state)
+}
+
+/*
+Returns the state of the given container and all of the components nested inside of it
+*/
+func GetLayoutState(root con.Container) (output LayoutState, err error) {
+	output.Version = LAYOUT_STATE_VERSION
+	output.Root = getContainerState(root)
+	output.Components = map[string]ComponentState{}
+	// zooming hides the zoomed component's siblings, so they're saved the way they were before the zoom
+	hiddenBeforeZoom := map[*con.Component]bool{}
+	con.WalkComponents(root.GetComponents(), func(component *con.Component) {
+		maps.Copy(hiddenBeforeZoom, component.GetHiddenBeforeZoom())
+	})
+	con.WalkComponents(root.GetComponents(), func(component *con.Component) {
+		id := component.GetID()
+		if id == "" || err != nil {
+			return
+		}
+		if _, exists := output.Components[id]; exists {
+			err = fmt.Errorf("more than one component has the ID %q", id)
+			return
+		}
+		output.Components[id] = getComponentState(component, hiddenBeforeZoom)
+	})
+	if err != nil {
+		return LayoutState{}, err
+	}
+	output.Focused = output.Root.Focused
+	return output, nil
+}
+
+/*
+Applies the given state onto the given container and the components nested inside of it,
+ignoring any IDs that don't match the components
+*/
+func ApplyLayoutState(root con.Container, state LayoutState) error {
+	if state.Version != LAYOUT_STATE_VERSION {
+		return fmt.Errorf("unsupported layout state version %d (expected %d)", state.Version, LAYOUT_STATE_VERSION)
+	}
+	con.WalkComponents(root.GetComponents(), func(component *con.Component) {
+		if componentState, ok := state.Components[component.GetID()]; ok && component.GetID() != "" {
+			applyComponentState(component, componentState)
+		}
+	})
+	// the containers are restored after their components, since a tab container can't select a hidden tab
+	con.WalkComponents(root.GetComponents(), func(component *con.Component) {
+		if componentState, ok := state.Components[component.GetID()]; ok && component.GetID() != "" && componentState.Container != nil {
+			// container models are held by value, so the changed model has to be put back into the component
+			if model, isModel := applyContainerState(component.GetModel(), *componentState.Container).(tea.Model); isModel {
+				component.SetModel(model)
+			}
+		}
+	})
+	applyContainerState(root, state.Root)
+
+	// focus is restored last, since whether a component can receive focus depends on the state of the containers around it
+	con.WalkComponents(root.GetComponents(), func(component *con.Component) {
+		if componentState, ok := state.Components[component.GetID()]; ok && component.GetID() != "" && componentState.Container != nil {
+			if model, isModel := applyFocusState(component.GetModel(), componentState.Container.Focused).(tea.Model); isModel {
+				component.SetModel(model)
+			}
+		}
+	})
+	focused := state.Root.Focused
+	if focused == "" {
+		focused = state.Focused
+	}
+	applyFocusState(root, focused)
+	return nil
+}
+
+/*
+Returns the state of the given component. Components whose hidden flag was recorded in the
+given map (because a sibling of theirs is zoomed) are saved with that hidden flag
+*/
+func getComponentState(component *con.Component, hiddenBeforeZoom map[*con.Component]bool) ComponentState {
+	// hidden components report a size range of zero, so read the size range from a visible copy
+	unhidden := *component
+	unhidden.SetHidden(false)
+	output := ComponentState{
+		Priority:      component.GetPriority(),
+		MinimumWidth:  unhidden.GetMinimumWidth(),
+		MaximumWidth:  unhidden.GetMaximumWidth(),
+		MinimumHeight: unhidden.GetMinimumHeight(),
+		MaximumHeight: unhidden.GetMaximumHeight(),
+		SizeHint: SizeHintState{
+			Kind:  SIZE_HINT_KIND_NAMES[component.GetSizeHint().GetKind()],
+			Value: component.GetSizeHint().GetValue(),
+		},
+		Hidden:       component.IsHidden(),
+		Collapsed:    component.IsCollapsed(),
+		ShowTitle:    component.IsShowingTitle(),
+		ShowShortcut: component.IsShowingShortcut(),
+	}
+	if hidden, zoomed := hiddenBeforeZoom[component]; zoomed {
+		output.Hidden = hidden
+	}
+	if cont, isCont := component.GetModel().(con.Container); isCont {
+		containerState := getContainerState(cont)
+		output.Container = &containerState
+	}
+	return output
+}
+
+func applyComponentState(component *con.Component, state ComponentState) {
+	component.SetPriority(state.Priority).
+		SetMinimumWidth(state.MinimumWidth).
+		SetMaximumWidth(state.MaximumWidth).
+		SetMinimumHeight(state.MinimumHeight).
+		SetMaximumHeight(state.MaximumHeight).
+		SetSizeHint(getSizeHint(state.SizeHint)).
+		SetHidden(state.Hidden).
+		SetCollapsed(state.Collapsed).
+		SetShowTitle(state.ShowTitle).
+		SetShowShortcut(state.ShowShortcut)
+}
+
+/*
+Returns the con.SizeHint described by the given SizeHintState (unknown kinds become con.AutoSize())
+*/
+func getSizeHint(state SizeHintState) con.SizeHint {
+	for kind, name := range SIZE_HINT_KIND_NAMES {
+		if name != state.Kind {
+			continue
+		}
+		switch kind {
+		case con.FIXED_SIZE:
+			return con.FixedSize(state.Value)
+		case con.PERCENT_SIZE:
+			return con.PercentSize(state.Value)
+		case con.WEIGHTED_SIZE:
+			return con.WeightedSize(state.Value)
+		}
+	}
+	return con.AutoSize()
+}
+
+func getContainerState(container con.Container) (output ContainerState) {
+	for _, component := range container.GetComponents() {
+		if component.GetID() != "" {
+			output.Order = append(output.Order, component.GetID())
+		}
+	}
+	if container.GetFocusHandler() != nil && container.GetFocusHandler().GetFocusedComponent() != nil {
+		output.Focused = container.GetFocusHandler().GetFocusedComponent().GetID()
+	}
+	switch container := container.(type) {
+	case lc.LinearContainerModel:
+		output.Direction = getDirectionName(container.GetDirection())
+	case *lc.LinearContainerModel:
+		output.Direction = getDirectionName(container.GetDirection())
+	case gc.GridContainerModel:
+		getGridContainerState(container, &output)
+	case *gc.GridContainerModel:
+		getGridContainerState(*container, &output)
+	case tc.TabContainerModel:
+		if active := container.GetActiveTab(); active != nil {
+			output.ActiveTab = active.GetID()
+		}
+	case *tc.TabContainerModel:
+		if active := container.GetActiveTab(); active != nil {
+			output.ActiveTab = active.GetID()
+		}
+	}
+	return
+}
+
+/*
+Applies the given state onto the given container (either a pointer to a container
+model, which is changed in place, or a container model, in which case the changed
+model is returned)
+*/
+func applyContainerState(container any, state ContainerState) any {
+	switch container := container.(type) {
+	case lc.LinearContainerModel:
+		applyLinearContainerState(&container, state)
+		return container
+	case *lc.LinearContainerModel:
+		applyLinearContainerState(container, state)
+	case tc.TabContainerModel:
+		applyTabContainerState(&container, state)
+		return container
+	case *tc.TabContainerModel:
+		applyTabContainerState(container, state)
+	case gc.GridContainerModel:
+		applyGridContainerState(&container, state)
+		return container
+	case *gc.GridContainerModel:
+		applyGridContainerState(container, state)
+	}
+	return container
+}
+
+/*
+Focuses the component with the given ID (among the components that can receive focus inside of the
+given container) in the given container, which is either a pointer to a container model (which is
+changed in place) or a container model (in which case the changed model is returned)
+*/
+func applyFocusState(container any, focused string) any {
+	switch container := container.(type) {
+	case lc.LinearContainerModel:
+		focusComponent(&container, focused)
+		return container
+	case *lc.LinearContainerModel:
+		focusComponent(container, focused)
+	case tc.TabContainerModel:
+		focusComponent(&container, focused)
+		return container
+	case *tc.TabContainerModel:
+		focusComponent(container, focused)
+	case gc.GridContainerModel:
+		focusComponent(&container, focused)
+		return container
+	case *gc.GridContainerModel:
+		focusComponent(container, focused)
+	}
+	return container
+}
+
+func focusComponent(container interface {
+	con.Container
+	SetFocusHandler(con.FocusHandler)
+}, focused string) {
+	if focused == "" || container.GetFocusHandler() == nil {
+		return
+	}
+	for _, component := range con.GetAllFocusableComponents(container.GetVisibleComponents()) {
+		if component.GetID() == focused {
+			container.SetFocusHandler(container.GetFocusHandler().SetFocusedComponent(component))
+		}
+	}
+}
+
+/*
+Returns the name of the given direction of a LinearContainerModel in DIRECTION_NAMES
+*/
+func getDirectionName(direction int) string {
+	for name, value := range DIRECTION_NAMES {
+		if value == direction {
+			return name
+		}
+	}
+	return ""
+}
+
+/*
+Records the rows, columns and placement of the components of the given GridContainerModel in the given state
+*/
+func getGridContainerState(container gc.GridContainerModel, output *ContainerState) {
+	for _, track := range container.GetRows() {
+		output.Rows = append(output.Rows, formatTrackSize(track))
+	}
+	for _, track := range container.GetColumns() {
+		output.Columns = append(output.Columns, formatTrackSize(track))
+	}
+	for _, cell := range container.GetCells() {
+		if id := cell.Component.GetID(); id != "" {
+			if output.Cells == nil {
+				output.Cells = map[string]GridCellState{}
+			}
+			output.Cells[id] = GridCellState{Row: cell.Row, Column: cell.Column, RowSpan: cell.RowSpan, ColumnSpan: cell.ColumnSpan}
+		}
+	}
+}
+
+func applyLinearContainerState(container *lc.LinearContainerModel, state ContainerState) {
+	if direction, ok := DIRECTION_NAMES[state.Direction]; ok {
+		container.SetDirection(direction)
+	}
+	for idx, component := range getRestoredOrder(container.GetComponents(), state.Order) {
+		container.MoveComponent(component, idx)
+	}
+}
+
+func applyTabContainerState(container *tc.TabContainerModel, state ContainerState) {
+	for idx, component := range getRestoredOrder(container.GetComponents(), state.Order) {
+		*container = container.MoveTab(component, idx)
+	}
+	// hidden tabs can't be selected, so the active tab is selected after the tabs' hidden flags were restored
+	for _, component := range container.GetComponents() {
+		if state.ActiveTab != "" && component.GetID() == state.ActiveTab {
+			container.SelectTab(component)
+		}
+	}
+}
+
+/*
+Returns the given components in the saved order. Only the components whose IDs are in the
+saved order are moved (between the positions they already occupy), so components that are
+new or have no ID stay where they are
+*/
+func getRestoredOrder(components []*con.Component, order []string) []*con.Component {
+	output := slices.Clone(components)
+	var slots []int
+	var saved []*con.Component
+	for idx, component := range components {
+		if component.GetID() != "" && slices.Contains(order, component.GetID()) {
+			slots = append(slots, idx)
+			saved = append(saved, component)
+		}
+	}
+	slices.SortStableFunc(saved, func(a *con.Component, b *con.Component) int {
+		return slices.Index(order, a.GetID()) - slices.Index(order, b.GetID())
+	})
+	for idx, slot := range slots {
+		output[slot] = saved[idx]
+	}
+	return output
+}
+
+/*
+Restores the rows and columns of the given GridContainerModel (unless a saved track can't be
+parsed, or the number of rows or columns changed) and the placement of its components
+*/
+func applyGridContainerState(container *gc.GridContainerModel, state ContainerState) {
+	if rows, ok := parseTrackSizes(state.Rows); ok && len(rows) == len(container.GetRows()) {
+		container.SetRows(rows)
+	}
+	if columns, ok := parseTrackSizes(state.Columns); ok && len(columns) == len(container.GetColumns()) {
+		container.SetColumns(columns)
+	}
+	for _, component := range container.GetComponents() {
+		if cell, ok := state.Cells[component.GetID()]; ok && component.GetID() != "" {
+			container.MoveComponent(component, cell.Row, cell.Column, cell.RowSpan, cell.ColumnSpan)
+		}
+	}
+}
+
+/*
+Parses the given track sizes (see parseTrackSize), returning false if any of them can't be parsed
+*/
+func parseTrackSizes(tracks []string) ([]gc.TrackSize, bool) {
+	output := make([]gc.TrackSize, len(tracks))
+	for idx, track := range tracks {
+		var err error
+		if output[idx], err = parseTrackSize(track); err != nil {
+			return nil, false
+		}
+	}
+	return output, true
+}
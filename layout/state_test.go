@@ -0,0 +1,70 @@
+package layout
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	con "github.com/argotnaut/vanitea/container"
+	lc "github.com/argotnaut/vanitea/linearcontainer"
+	tc "github.com/argotnaut/vanitea/tabcontainer"
+)
+
+type leafModel struct{}
+
+func (m leafModel) Init() tea.Cmd                       { return nil }
+func (m leafModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (m leafModel) View() string                        { return "" }
+
+func TestSaveAndRestoreFocus(t *testing.T) {
+	left := con.ComponentFromModel(leafModel{}).SetID("left")
+	a := con.ComponentFromModel(leafModel{}).SetID("a")
+	b := con.ComponentFromModel(leafModel{}).SetID("b")
+	first := con.ComponentFromModel(leafModel{}).SetID("first")
+	second := con.ComponentFromModel(leafModel{}).SetID("second")
+	split := lc.NewLinearContainerFromComponents([]*con.Component{a, b})
+	split.SetFocusHandler(split.GetFocusHandler().SetFocusedComponent(b))
+	splitComponent := con.ComponentFromModel(*split).SetID("split")
+	tabs := tc.NewTabContainerFromComponents([]*con.Component{first, second})
+	*tabs = tabs.SelectTab(second)
+	tabs.SetFocusHandler(tabs.GetFocusHandler().SetFocusedComponent(second))
+	tabsComponent := con.ComponentFromModel(*tabs).SetID("tabs")
+	root := lc.NewLinearContainerFromComponents([]*con.Component{left, splitComponent, tabsComponent})
+	root.SetFocusHandler(root.GetFocusHandler().SetFocusedComponent(left))
+
+	saved, err := SaveLayout(root)
+	if err != nil {
+		t.Fatalf("SaveLayout returned an error: %v", err)
+	}
+
+	// move focus everywhere before restoring it
+	root.SetFocusHandler(root.GetFocusHandler().SetFocusedComponent(a))
+	changedSplit := splitComponent.GetModel().(lc.LinearContainerModel)
+	changedSplit.SetFocusHandler(changedSplit.GetFocusHandler().SetFocusedComponent(a))
+	splitComponent.SetModel(changedSplit)
+	changedTabs := tabsComponent.GetModel().(tc.TabContainerModel).SelectTab(first)
+	changedTabs.SetFocusHandler(changedTabs.GetFocusHandler().SetFocusedComponent(first))
+	tabsComponent.SetModel(changedTabs)
+
+	if err := RestoreLayout(root, saved); err != nil {
+		t.Fatalf("RestoreLayout returned an error: %v", err)
+	}
+	restoredTabs := tabsComponent.GetModel().(tc.TabContainerModel)
+	cases := []struct {
+		name    string
+		focused *con.Component
+		want    *con.Component
+	}{
+		{name: "root", focused: root.GetFocusHandler().GetFocusedComponent(), want: left},
+		{name: "nested linear container", focused: splitComponent.GetModel().(lc.LinearContainerModel).GetFocusHandler().GetFocusedComponent(), want: b},
+		{name: "nested tab container", focused: restoredTabs.GetFocusHandler().GetFocusedComponent(), want: second},
+		{name: "active tab", focused: restoredTabs.GetActiveTab(), want: second},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.focused != c.want {
+				t.Errorf("focused %q, want %q", c.focused.GetID(), c.want.GetID())
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package linearcontainer
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	con "github.com/argotnaut/vanitea/container"
+)
+
+/*
+Inserts the given component at the given index in the LinearContainerModel's list of
+components (appending it if the index is negative or past the end)
+*/
+func (m *LinearContainerModel) InsertComponent(component *con.Component, idx int) *LinearContainerModel {
+	if component == nil || slices.Contains(m.components, component) {
+		return m
+	}
+	if idx < 0 || idx > len(m.components) {
+		idx = len(m.components)
+	}
+	m.components = con.Splice(m.components, idx, idx, component)
+	m.SetFocusHandler(m.GetFocusHandler())
+	m.ensureValidFocus()
+	return m
+}
+
+/*
+Removes the given component from the LinearContainerModel, returning the tea.Cmd from unmounting
+it (see con.Component.Unmount). If the focused component was removed, focus returns to where it
+was before it was trapped inside of the removed component, or else moves to the first component
+that can receive it
+*/
+func (m *LinearContainerModel) RemoveComponent(component *con.Component) tea.Cmd {
+	idx := slices.Index(m.components, component)
+	if idx < 0 {
+		return nil
+	}
+	cmd := con.RemoveComponent(m, component, func() {
+		m.components = con.Splice(m.components, idx, idx+1)
+	})
+	m.ensureValidFocus()
+	return cmd
+}
+
+/*
+Puts the given replacement in the place of the given component (which is removed as by
+RemoveComponent), returning the tea.Cmd from unmounting the replaced component. If the
+replaced component had focus, the replacement gets it (if it can receive it)
+*/
+func (m *LinearContainerModel) ReplaceComponent(component *con.Component, replacement *con.Component) tea.Cmd {
+	idx := slices.Index(m.components, component)
+	if idx < 0 || replacement == nil {
+		return nil
+	}
+	cmd := con.ReplaceComponent(m, component, replacement, func() tea.Cmd {
+		m.InsertComponent(replacement, idx)
+		return m.RemoveComponent(component)
+	})
+	m.ensureValidFocus()
+	return cmd
+}
+
+/*
+Returns the component with the given ID among the LinearContainerModel's own components
+(not the ones nested inside of them), or nil if there isn't one
+*/
+func (m LinearContainerModel) getOwnComponentByID(id string) *con.Component {
+	for _, component := range m.components {
+		if id != "" && component.GetID() == id {
+			return component
+		}
+	}
+	return nil
+}
+
+/*
+Changes the LinearContainerModel's components as the given message describes (if it targets this
+container, otherwise it's passed on to the nested container it targets) and lays them out again
+*/
+func (m *LinearContainerModel) handleComponentsMsg(msg con.ComponentsMsg) tea.Cmd {
+	if msg.GetContainerID() != "" {
+		cmd, handler := con.ForwardComponentsMsg(m.GetComponents(), m.GetFocusHandler(), msg)
+		m.SetFocusHandler(handler)
+		m.ensureValidFocus()
+		return cmd
+	}
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case con.InsertComponentMsg:
+		m.InsertComponent(msg.Component, msg.Index)
+	case con.RemoveComponentMsg:
+		cmd = m.RemoveComponent(m.getOwnComponentByID(msg.ComponentID))
+	case con.ReplaceComponentMsg:
+		cmd = m.ReplaceComponent(m.getOwnComponentByID(msg.ComponentID), msg.Component)
+	case con.MoveComponentMsg:
+		if component := m.getOwnComponentByID(msg.ComponentID); component != nil {
+			m.MoveComponent(component, msg.Index)
+		}
+	}
+	return tea.Batch(cmd, con.RelayoutContainer(m, m.size))
+}
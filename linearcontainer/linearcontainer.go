@@ -1,18 +1,21 @@
 package linearcontainer
 
 import (
+	"math"
 	"slices"
-	"sort"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	con "github.com/argotnaut/vanitea/container"
+	"github.com/argotnaut/vanitea/utils"
 )
 
 const (
 	HORIZONTAL int = iota
 	VERTICAL
+	// Layers the components on top of each other, with the last visible component on top
 	STACK
 )
 
@@ -20,10 +23,35 @@ type LinearContainerModel struct {
 	focusHandler con.FocusHandler
 	components   []*con.Component
 	direction    int
+	// The number of empty cells between adjacent components
+	gap int
+	// The number of empty cells between the container's edges and its components (top, right, bottom, left)
+	padding [4]int
+	// How components are aligned along the minor axis
+	alignment int
+	// Whether to draw a separator line between adjacent components
+	showSeparators bool
+	// The style whose border characters and color are used to draw separators
+	separatorStyle lipgloss.Style
+	// The area the container was last laid out in
+	size tea.WindowSizeMsg
+	// The number of cells the resize actions grow or shrink a pane by
+	resizeStep int
+	// The mouse drag currently moving the boundary between two components, if any
+	drag *paneDrag
+	// The container's last rendering (shared by copies of the container)
+	viewCache *con.RenderCache
+	// The changes the container's Actions queued up for it (shared by copies of the container)
+	changes *con.ChangeQueue
 }
 
 func NewLinearContainer() *LinearContainerModel {
-	lc := LinearContainerModel{}
+	lc := LinearContainerModel{
+		alignment:      ALIGN_CENTER,
+		separatorStyle: con.BORDER_STYLE,
+		viewCache:      &con.RenderCache{},
+		changes:        &con.ChangeQueue{},
+	}
 	lc.SetFocusHandler(con.NewDefaultLinearFocusHandler(lc.GetComponents))
 	return &lc
 }
@@ -40,7 +68,7 @@ func NewLinearContainerFromComponents(components []*con.Component) *LinearContai
 func (m LinearContainerModel) Init() tea.Cmd {
 	var cmds []tea.Cmd
 	for _, component := range m.GetComponents() {
-		cmds = append(cmds, component.GetModel().Init())
+		cmds = append(cmds, component.Init())
 	}
 	return tea.Batch(cmds...)
 }
@@ -53,6 +81,43 @@ func (m LinearContainerModel) GetActions() (output []con.Action) {
 	for _, component := range m.components {
 		output = append(output, component.GetActions()...)
 	}
+	output = append(output, m.getResizeActions()...)
+	output = append(output, m.getZoomActions()...)
+	output = append(output, m.getCollapseActions()...)
+	return output
+}
+
+/*
+Returns the action for zooming the focused component to fill the container. Like the
+resize actions, it's only provided by the container the focused component belongs to directly
+*/
+func (m LinearContainerModel) getZoomActions() []con.Action {
+	focused := m.GetFocusHandler().GetFocusedComponent()
+	if focused == nil || !slices.Contains(m.GetComponents(), focused) {
+		return nil
+	}
+	return []con.Action{
+		con.NewToggleZoomAction(m.changes),
+	}
+}
+
+/*
+Returns the actions for collapsing the focused component and for expanding all of the collapsed
+components. Like the resize actions, they're only provided by the container the focused component
+belongs to directly (stacked components don't share space, so they can't be collapsed)
+*/
+func (m LinearContainerModel) getCollapseActions() (output []con.Action) {
+	focused := m.GetFocusHandler().GetFocusedComponent()
+	if m.IsStacked() || focused == nil || !slices.Contains(m.GetComponents(), focused) {
+		return nil
+	}
+	output = append(output, con.NewToggleCollapseAction(m.changes))
+	for _, component := range m.GetComponents() {
+		if component.IsCollapsed() {
+			output = append(output, con.NewExpandAllAction(m.changes))
+			break
+		}
+	}
 	return output
 }
 
@@ -66,15 +131,51 @@ func (m LinearContainerModel) GetVisibleComponents() (output []*con.Component) {
 }
 
 func (m *LinearContainerModel) SetFocusHandler(handler con.FocusHandler) {
-	m.focusHandler = handler.SetComponentDelegate(m.GetComponents)
+	m.focusHandler = handler.SetComponentDelegate(m.getFocusCandidates)
+}
+
+/*
+Returns the components that the focus handler may give focus to (when stacked,
+only the top-most visible layer can receive focus)
+*/
+func (m LinearContainerModel) getFocusCandidates() []*con.Component {
+	if m.IsStacked() {
+		if top := m.GetTopLayer(); top != nil {
+			return []*con.Component{top}
+		}
+		return nil
+	}
+	return m.GetComponents()
 }
 
 func (m LinearContainerModel) GetFocusHandler() con.FocusHandler {
 	return m.focusHandler
 }
 
+/*
+Returns the LinearContainerModel with the given component as its focused component (see con.FocusSyncable)
+*/
+func (m LinearContainerModel) SyncFocus(focused *con.Component) tea.Model {
+	if m.GetFocusHandler() != nil {
+		m.SetFocusHandler(m.GetFocusHandler().SetFocusedComponent(focused))
+	}
+	return m
+}
+
+/*
+Returns the direction in which the components are laid out (HORIZONTAL, VERTICAL or STACK)
+*/
+func (m LinearContainerModel) GetDirection() int {
+	return m.direction
+}
+
 func (m *LinearContainerModel) SetDirection(direction int) *LinearContainerModel {
 	m.direction = direction
+	// the focus handler's delegate depends on the direction, so it needs to be refreshed
+	if m.GetFocusHandler() != nil {
+		m.SetFocusHandler(m.GetFocusHandler())
+		m.ensureValidFocus()
+	}
 	return m
 }
 
@@ -86,6 +187,76 @@ func (m LinearContainerModel) IsHorizontal() bool {
 	return m.direction == HORIZONTAL
 }
 
+func (m LinearContainerModel) IsStacked() bool {
+	return m.direction == STACK
+}
+
+/*
+Returns the top-most visible layer of the LinearContainerModel (the last
+visible component), or nil if there are no visible components
+*/
+func (m LinearContainerModel) GetTopLayer() *con.Component {
+	visible := m.GetVisibleComponents()
+	if len(visible) < 1 {
+		return nil
+	}
+	return visible[len(visible)-1]
+}
+
+/*
+Moves the given component to the given index in the LinearContainerModel's list
+of components (which, when stacked, is its position in the z-order)
+*/
+func (m *LinearContainerModel) MoveComponent(component *con.Component, newIdx int) *LinearContainerModel {
+	idx := slices.Index(m.components, component)
+	if idx < 0 {
+		return m
+	}
+	newIdx = utils.ClampInt(newIdx, 0, len(m.components)-1)
+	m.components = con.Splice(con.Splice(m.components, idx, idx+1), newIdx, newIdx, component)
+	m.SetFocusHandler(m.GetFocusHandler())
+	m.ensureValidFocus()
+	return m
+}
+
+/*
+Moves the given component one layer up in the z-order
+*/
+func (m *LinearContainerModel) RaiseComponent(component *con.Component) *LinearContainerModel {
+	return m.MoveComponent(component, slices.Index(m.components, component)+1)
+}
+
+/*
+Moves the given component one layer down in the z-order
+*/
+func (m *LinearContainerModel) LowerComponent(component *con.Component) *LinearContainerModel {
+	return m.MoveComponent(component, max(0, slices.Index(m.components, component)-1))
+}
+
+/*
+Moves the given component to the top of the z-order
+*/
+func (m *LinearContainerModel) RaiseComponentToTop(component *con.Component) *LinearContainerModel {
+	return m.MoveComponent(component, len(m.components)-1)
+}
+
+/*
+Moves the given component to the bottom of the z-order
+*/
+func (m *LinearContainerModel) LowerComponentToBottom(component *con.Component) *LinearContainerModel {
+	return m.MoveComponent(component, 0)
+}
+
+/*
+Moves focus to the first component that can receive it if the focused component can't
+(because it was hidden or collapsed, because a focus scope was opened or closed, or, when
+stacked, because it isn't in the top-most visible layer). Focus that was given to a component outside of the LinearContainerModel
+(by a parent container) is left alone
+*/
+func (m *LinearContainerModel) ensureValidFocus() {
+	con.EnsureValidFocus(m, m.getFocusCandidates())
+}
+
 func (m LinearContainerModel) GetComponent(idx int) *con.Component {
 	return m.GetComponents()[idx]
 }
@@ -118,27 +289,32 @@ func (m LinearContainerModel) SetMajorAndMinorAxes(msg *tea.WindowSizeMsg, major
 }
 
 /*
-Returns the maximum width or height of the Component, depending on whether the
-given LinearContainerModel is horizontal or vertical
+Returns the maximum width or height of the Component (see con.Component.GetLayoutBounds),
+depending on whether the given LinearContainerModel is horizontal or vertical
 */
-func (linearContainer LinearContainerModel) getMaximumSize(component con.Component) int {
-	if linearContainer.IsHorizontal() {
-		return component.GetMaximumWidth()
-	} else {
-		return component.GetMaximumHeight()
-	}
+func (linearContainer LinearContainerModel) getMaximumSize(component *con.Component) int {
+	_, maximum := component.GetLayoutBounds(linearContainer.GetComponentStyle(component))
+	return linearContainer.GetSizeAlongMajorAxis(maximum)
 }
 
 /*
-Returns the minimum width or height of the Component, depending on whether the
-given LinearContainerModel is horizontal or vertical
+Returns the minimum width or height of the Component (see con.Component.GetLayoutBounds),
+depending on whether the given LinearContainerModel is horizontal or vertical
 */
-func (linearContainer LinearContainerModel) getMinimumSize(component con.Component) int {
-	if linearContainer.IsHorizontal() {
-		return component.GetMinimumWidth()
-	} else {
-		return component.GetMinimumHeight()
+func (linearContainer LinearContainerModel) getMinimumSize(component *con.Component) int {
+	minimum, _ := component.GetLayoutBounds(linearContainer.GetComponentStyle(component))
+	return linearContainer.GetSizeAlongMajorAxis(minimum)
+}
+
+/*
+Returns the size hint the given component is laid out with (a zoomed component
+ignores its size hint so that it can take all of the container's space)
+*/
+func (linearContainer LinearContainerModel) getSizeHint(component con.Component) con.SizeHint {
+	if component.IsZoomed() {
+		return con.AutoSize()
 	}
+	return component.GetSizeHint()
 }
 
 /*
@@ -148,10 +324,7 @@ func (m LinearContainerModel) GetComponentStyle(component *con.Component) lipglo
 	if component == nil {
 		return con.NO_BORDER_STYLE
 	}
-	if m.GetFocusHandler().GetFocusedComponent() == component {
-		return component.GetFocusBorderStyle()
-	}
-	return component.GetBorderStyle()
+	return component.GetCurrentBorderStyle(m.GetFocusHandler().GetFocusedComponent() == component)
 }
 
 func (m LinearContainerModel) GetComponentStyleByIndex(componentIdx int) lipgloss.Style {
@@ -163,164 +336,168 @@ Returns the string rendering of the given component (including the border given
 the linear container) if it were to be resized to the given size
 */
 func (m LinearContainerModel) viewComponentAtSize(component con.Component, size tea.WindowSizeMsg) string {
+	// the trial rendering mustn't replace the component's cached rendering at its actual size
+	component.DetachRenderCache()
 	component.Update(size)
 	return m.ViewComponent(&component)
 }
 
 /*
-Given a target size, the returns the size a specific component should be set to, according to
-the available space laid out by containerSize and the Component's max/min width/height. It also
-includes logic to keep a component from growing beyond the maximum dimensions of its content,
-if the component has shrinkToContent set to true
-
-componentIdx: int - The index of the component in the LinearContainerModel's
-list of Components
-
-containerSize: tea.WindowSizeMsg - The WindowSizeMsg which defines the area available to
-the LinearContainer
+Returns the size the given component's content (including its border) takes up if the component
+were to be resized to the given size. Models that are con.ContentSizers report it themselves; the
+others are rendered at the given size to measure it (and are assumed to be able to use any amount of space)
+*/
+func (m LinearContainerModel) getContentSize(component *con.Component, size tea.WindowSizeMsg) con.ContentSize {
+	if contentSize, isSizer := component.GetContentSize(size, m.GetComponentStyle(component)); isSizer {
+		return contentSize
+	}
+	rendering := m.viewComponentAtSize(*component, size)
+	return con.ContentSize{
+		Preferred: tea.WindowSizeMsg{Width: lipgloss.Width(rendering), Height: lipgloss.Height(rendering)},
+		Maximum:   tea.WindowSizeMsg{Width: math.MaxInt, Height: math.MaxInt},
+	}
+}
 
-newSize: int - The new size of the major axis of the Component (if the
-LinearContainerModel has direction horizontal, the new size would
-refer to the width of components)
+/*
+Resizes the components according to their dimensions and the dimensions of the
+LinearContainerModel
 */
-func (m LinearContainerModel) getNewComponentSize(componentIdx int, containerSize tea.WindowSizeMsg, newSize int) (newMsg tea.WindowSizeMsg, hitMaxSize bool) {
-	newMsg = containerSize
-	component := m.GetComponent(componentIdx) // For brevity, get the component at componentIdx
-	if m.IsHorizontal() {
-		newMsg.Height = component.GetClampedHeight(containerSize.Height) // Height isn't the major axis, so use as much of the WindowSizeMsg's hight as the Component's MaximumHeight will allow
-		newMsg.Width = component.GetClampedWidth(newSize)                // Width is the major axis, so try to set the width of the new dimensions to newSize
-
-		if component.ShrinkToContent() {
-			widthOfContentAtNewSize := lipgloss.Width(m.viewComponentAtSize(*component, newMsg)) // Calculate what the width of the components view would be at the proposed new size
-			if widthOfContentAtNewSize < newMsg.Width {                                          // If resizing this component to this new size would leave some empty space when its model had rendered
-				newMsg.Width = component.GetClampedWidth(widthOfContentAtNewSize) // Set its width to however much its model would actually use
-				hitMaxSize = true
-			}
-		}
-	} else {
+func (m *LinearContainerModel) ResizeComponents(containerSize tea.WindowSizeMsg) tea.Cmd {
+	m.size = containerSize
+	if m.IsStacked() {
+		return m.resizeStackedComponents(containerSize)
+	}
+	// padding and gaps aren't available to the components
+	containerSize = m.getInnerSize(containerSize)
+	// work out the size of each component along the major axis (see solveLayout)
+	items := make([]layoutItem, len(m.GetComponents()))
+	for i, component := range m.GetComponents() {
+		items[i] = m.getLayoutItem(component, containerSize)
+	}
+	majorAxisSizes := solveLayout(items, m.GetSizeAlongMajorAxis(containerSize))
 
-		newMsg.Width = component.GetClampedWidth(containerSize.Width) // Width isn't the major axis, so use as much of the WindowSizeMsg's width as the Component's MaximumWidth will allow
-		newMsg.Height = component.GetClampedHeight(newSize)           // Height is the major axis, so try to set the height of the new dimensions to newSize
-		if component.ShrinkToContent() {
-			heightOfContentAtNewSize := lipgloss.Height(m.viewComponentAtSize(*component, newMsg)) // Calculate what the height of the components view would be at the proposed new size
-			if heightOfContentAtNewSize < newMsg.Height {                                          // If resizing this component to this new size would leave some empty space when its model had rendered
-				newMsg.Height = component.GetClampedHeight(heightOfContentAtNewSize) // Set its height to however much its model would actually use
-				hitMaxSize = true
-			}
+	// set all of the components to their new sizes
+	var cmds []tea.Cmd
+	for i, component := range m.GetComponents() {
+		var size tea.WindowSizeMsg
+		switch {
+		case component.IsHidden():
+		case component.IsCollapsed():
+			m.SetMajorAndMinorAxes(&size, majorAxisSizes[i], m.GetSizeAlongMinorAxis(containerSize))
+		default:
+			m.SetMajorAndMinorAxes(
+				&size,
+				majorAxisSizes[i],
+				m.getMinorAxisSizeForComponent(component, m.GetSizeAlongMinorAxis(containerSize)),
+			)
 		}
+		cmds = append(cmds, resizeComponentModelForStyle(component, size, *m))
 	}
-	if newMsg.Width >= component.GetMaximumWidth() || newMsg.Height >= component.GetMaximumHeight() {
-		hitMaxSize = true
-	}
-	return
+	m.updateComponentBounds()
+	return tea.Batch(cmds...)
 }
 
 /*
-Returns the amount of space (in characters) along the major axis that remains
-unoccupied by the LinearContainerModel's components
-
-componentComponentSizes []tea.WindowSizeMsg - The width and height of each component
-containerSize tea.WindowSizeMsg - The width and height available to the LinearContainerModel
+Resizes each of the components to fill as much of the LinearContainerModel as their
+minimum and maximum dimensions allow, since stacked components don't share space
 */
-func (m LinearContainerModel) calculateRemainingSpace(
-	componentComponentSizes []tea.WindowSizeMsg,
-	containerSize tea.WindowSizeMsg,
-) int {
-	remainingSpace := m.GetSizeAlongMajorAxis(containerSize)
-	for _, componentSize := range componentComponentSizes {
-		remainingSpace -= max(m.GetSizeAlongMajorAxis(componentSize), 0)
+func (m *LinearContainerModel) resizeStackedComponents(containerSize tea.WindowSizeMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, component := range m.GetComponents() {
+		cmds = append(cmds, resizeComponentModelForStyle(
+			component,
+			component.GetLayoutClampedSize(containerSize, m.GetComponentStyle(component)),
+			*m,
+		))
 	}
-	return max(0, remainingSpace)
+	m.updateComponentBounds()
+	return tea.Batch(cmds...)
 }
 
 /*
-Resizes the components according to their dimensions and the dimensions of the
-LinearContainerModel
+Records the area each visible component occupies within the LinearContainerModel
+(mirroring how View joins the components' renderings together), which also updates
+the components' absolute geometry
 */
-func (m *LinearContainerModel) ResizeComponents(containerSize tea.WindowSizeMsg) tea.Cmd {
-	// holds the sizes of every component that's getting resized (update this every time they change)
-	var sizes []tea.WindowSizeMsg
-	// holds the indices of the remaining components that can still grow
-	var growableComponents []int
-
-	// 1. set every component to its minimum width
-	for i := range len(m.GetComponents()) {
-		newSize, _ := m.getNewComponentSize(i, containerSize, m.getMinimumSize(*(m.GetComponent(i))))
-		sizes = append(sizes, newSize)
-		// if the component can still grow
-		if m.GetSizeAlongMajorAxis(newSize) < m.getMaximumSize(*(m.GetComponent(i))) {
-			// add it to the list of growable components
-			growableComponents = append(growableComponents, i)
+func (m LinearContainerModel) updateComponentBounds() {
+	if m.IsStacked() {
+		m.updateStackedComponentBounds()
+		return
+	}
+	minorAxisSize := m.getContentMinorAxisSize()
+	majorAxisOffset, _ := m.getMajorAxisPadding()
+	minorAxisPadding, _ := m.getMinorAxisPadding()
+	for _, component := range m.GetVisibleComponents() {
+		size := component.GetSize()
+		// components are joined according to the alignment along the minor axis
+		minorAxisOffset := minorAxisPadding + utils.Round(float64(minorAxisSize-m.GetSizeAlongMinorAxis(size))*float64(m.getAlignmentPosition()))
+		bounds := con.Rectangle{Width: size.Width, Height: size.Height}
+		if m.IsHorizontal() {
+			bounds.X, bounds.Y = majorAxisOffset, minorAxisOffset
+		} else {
+			bounds.X, bounds.Y = minorAxisOffset, majorAxisOffset
 		}
-		// update the remaining space
+		component.SetLayout(bounds, m.GetComponentStyle(component))
+		majorAxisOffset += m.GetSizeAlongMajorAxis(size) + m.getGapSize()
 	}
-	// sort the indices of growable components in ascending order of priority
-	sort.Slice(growableComponents, func(i int, j int) bool {
-		return m.GetComponent(i).GetPriority() < m.GetComponent(j).GetPriority()
-	})
+}
 
-	// keeps track of how much space remains unclaimed by the growing components
-	getRemainingSpace := func() int { return m.calculateRemainingSpace(sizes, containerSize) }
-	remainingSpace := getRemainingSpace()
+/*
+Records the area each visible component occupies when layered according to its stack anchor
+and offsets (mirroring how utils.PlaceStacked positions the layers in View)
+*/
+func (m LinearContainerModel) updateStackedComponentBounds() {
+	canvas := m.GetFullContainerSize()
+	for _, component := range m.GetVisibleComponents() {
+		size := component.GetSize()
+		anchor := component.GetStackAnchor()
+		vOffset, hOffset := component.GetStackOffset()
+		component.SetLayout(con.Rectangle{
+			X:      hOffset - int(anchor.X*float64(size.Width)) + int(anchor.X*float64(canvas.Width)),
+			Y:      vOffset - int(anchor.Y*float64(size.Height)) + int(anchor.Y*float64(canvas.Height)),
+			Width:  size.Width,
+			Height: size.Height,
+		}, m.GetComponentStyle(component))
+	}
+}
 
-	// an even share of the remaining space for each growable component
-	getEvenShare := func() int {
-		if len(growableComponents) < 1 {
-			return 0
-		}
-		return int(remainingSpace / len(growableComponents))
-	}
-	evenShare := getEvenShare()
-	// while there are still growable components and an integer amount of space available to each of them
-	for len(growableComponents) > 0 && evenShare != 0 {
-
-		for growableIdx := 0; growableIdx < len(growableComponents); growableIdx++ {
-			// try to grow each growable component to an even share of the remaining space
-			componentIdx := growableComponents[growableIdx] // get the index of the component in m.Components
-			newSize, hitMaxSize := m.getNewComponentSize(
-				componentIdx,
-				containerSize,
-				m.GetSizeAlongMajorAxis(sizes[componentIdx])+evenShare,
-			)
-			sizes[componentIdx] = newSize
-			// if the component hit its maximum size
-			if hitMaxSize {
-				// remove it from the list of growable components
-				growableComponents = slices.Delete(
-					growableComponents,
-					growableIdx,
-					growableIdx+1,
-				)
-				break
-			}
-		}
-		remainingSpace = getRemainingSpace()
-		evenShare = getEvenShare()
-	}
-
-	// if there are still components to grow, but not enough remaining space to share evenly between them
-	if len(growableComponents) > 0 && evenShare < 1 {
-		// give all remaining space to the growable with the highest priority
-		componentIdx := growableComponents[0] // get the index of the component in m.Components
-		newSize, _ := m.getNewComponentSize(
-			componentIdx,
-			containerSize,
-			m.GetSizeAlongMajorAxis(sizes[componentIdx])+remainingSpace,
-		)
-		sizes[componentIdx] = newSize
+/*
+Routes the given tea.MouseMsg to the visible component under the cursor (with its
+coordinates translated into that component's space) and, if the message is a click,
+moves focus to the most deeply nested focusable component under the cursor
+*/
+func (m *LinearContainerModel) handleMouseMsg(msg tea.MouseMsg) tea.Cmd {
+	if cmd, isDrag := m.handlePaneDrag(msg); isDrag {
+		return cmd
 	}
+	return con.RouteMouseMsg(m, msg, m.handleClick)
+}
 
-	// set all components that got resized to their new sizes
-	var cmds []tea.Cmd
-	for i := range len(sizes) {
-		component := m.GetComponent(i)
-		cmd := resizeComponentModelForStyle(component, sizes[i], *m)
-		cmds = append(cmds, cmd)
+/*
+Brings the clicked layer to the top when stacked (since only the top layer can hold focus), and
+expands the clicked component if it's collapsed (focusing the first component inside of it)
+*/
+func (m *LinearContainerModel) handleClick(target *con.Component) (tea.Cmd, bool) {
+	if m.IsStacked() {
+		m.RaiseComponentToTop(target)
 	}
-	return tea.Batch(cmds...)
+	if !target.IsCollapsed() {
+		return nil, false
+	}
+	target.SetCollapsed(false)
+	cmd := m.ResizeComponents(m.size)
+	if focusTargets := con.GetAllFocusableComponents([]*con.Component{target}); len(focusTargets) > 0 {
+		m.SetFocusHandler(m.GetFocusHandler().SetFocusedComponent(focusTargets[0]))
+	}
+	return cmd, true
 }
 
 func resizeComponentModelForStyle(component *con.Component, size tea.WindowSizeMsg, m LinearContainerModel) tea.Cmd {
+	if component.IsCollapsed() {
+		// a collapsed component only renders its border, so its model doesn't need to be resized
+		component.SetSize(size)
+		return nil
+	}
 	model, cmd := component.GetModel().Update(tea.WindowSizeMsg{
 		Width:  size.Width - m.GetComponentStyle(component).GetHorizontalFrameSize(),
 		Height: size.Height - m.GetComponentStyle(component).GetVerticalFrameSize(),
@@ -331,29 +508,33 @@ func resizeComponentModelForStyle(component *con.Component, size tea.WindowSizeM
 }
 
 func (m LinearContainerModel) GetFullContainerSize() (output tea.WindowSizeMsg) {
-	majorAxisSize := 0
-	minorAxisSize := 0
+	if m.IsStacked() {
+		for _, component := range m.GetVisibleComponents() {
+			output.Width = max(output.Width, component.GetSize().Width)
+			output.Height = max(output.Height, component.GetSize().Height)
+		}
+		return
+	}
+	majorAxisSize := m.getMajorAxisSpacing()
 	for _, component := range m.GetVisibleComponents() {
 		majorAxisSize += m.GetSizeAlongMajorAxis(component.GetSize())
-		minorAxisSize = max(
-			minorAxisSize,
-			m.GetSizeAlongMinorAxis(component.GetSize()),
-		)
 	}
+	minorAxisSize := m.getContentMinorAxisSize() + m.getMinorAxisSpacing()
 	m.SetMajorAndMinorAxes(&output, majorAxisSize, minorAxisSize)
 	return
 }
 
-func (m LinearContainerModel) ViewComponent(component *con.Component) string {
-	if lc, isLC := component.GetModel().(LinearContainerModel); isLC {
-		// if component is a LinearContainerModel, make sure it gets m's FocusHandler
-		lc.SetFocusHandler(
-			lc.focusHandler.SetFocusedComponent(
-				m.GetFocusHandler().GetFocusedComponent(),
-			),
-		)
-		component.SetModel(lc)
+/*
+Returns the size of the largest visible component along the minor axis
+*/
+func (m LinearContainerModel) getContentMinorAxisSize() (output int) {
+	for _, component := range m.GetVisibleComponents() {
+		output = max(output, m.GetSizeAlongMinorAxis(component.GetSize()))
 	}
+	return
+}
+
+func (m LinearContainerModel) ViewComponent(component *con.Component) string {
 	if m.GetFocusHandler().GetFocusedComponent() == component {
 		return component.RenderFocused()
 	} else {
@@ -361,45 +542,124 @@ func (m LinearContainerModel) ViewComponent(component *con.Component) string {
 	}
 }
 
+/*
+Updates the LinearContainerModel and its components with the given message, then sends the components the
+lifecycle messages (see con.DeliverLifecycle) and delivers focus (see con.DeliverFocus)
+in case the message laid them out, showed or hid them, or changed which one has focus
+*/
 func (m LinearContainerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return con.UpdateContainer(m, msg, m.update)
+}
+
+func (m LinearContainerModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
+	con.ApplyChanges(m.changes, &m)
+	// components may have been hidden, collapsed or lowered (e.g. by an Action) since the last message
+	m.ensureValidFocus()
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if m.GetFocusHandler().IsFocusKey(msg.String()) {
-			m.SetFocusHandler(m.GetFocusHandler().HandleFocusKey(msg.String()))
-		} else {
-			keyUpdateCmd := m.GetFocusHandler().GetFocusedComponent().Update(msg)
-			return m, keyUpdateCmd
+		if handler, isFocusKey := con.HandleNestedFocusKey(m.GetComponents(), m.GetFocusHandler(), msg.String()); isFocusKey {
+			// focus keys are only meant for the outermost container, so they aren't passed on to the components
+			m.SetFocusHandler(handler)
+			return m, con.GetFocusOverlayCmd(m.GetFocusHandler())
 		}
+		return m, con.ForwardKey(m.GetComponents(), m.GetFocusHandler(), msg)
 	case tea.WindowSizeMsg:
 		return m, (&m).ResizeComponents(msg)
+	case con.RelayoutMsg:
+		return m, con.RelayoutContainer(&m, m.size)
+	case tea.MouseMsg:
+		return m, (&m).handleMouseMsg(msg)
+	case con.ComponentsMsg:
+		return m, (&m).handleComponentsMsg(msg)
+	case con.SelectedMsg:
+		return m, con.HandleSelectedMsg(m.GetComponents(), msg)
 	}
+	// the components are only resized when the container is laid out (see ResizeComponents)
 	for _, component := range m.GetComponents() {
-		model, cmd := component.GetModel().Update(msg)
-		component.SetModel(model)
-		cmds = append(cmds, cmd)
-		resizeCmd := resizeComponentModelForStyle(component, component.GetSize(), m)
-		cmds = append(cmds, resizeCmd)
+		cmds = append(cmds, component.Update(msg))
 	}
 	return m, tea.Batch(cmds...)
 }
 
-func (m LinearContainerModel) View() (s string) {
-	var views []string
-	// Collect all the individual renderings for all the components
+/*
+Renders the visible components on top of each other, starting from a blank canvas the size
+of the largest component and placing each layer at its stack anchor and offsets
+*/
+func (m LinearContainerModel) viewStacked() string {
+	canvasSize := m.GetFullContainerSize()
+	if canvasSize.Width < 1 || canvasSize.Height < 1 {
+		return ""
+	}
+	output := strings.TrimSuffix(
+		strings.Repeat(strings.Repeat(utils.WHITESPACE_CHAR, canvasSize.Width)+"\n", canvasSize.Height),
+		"\n",
+	)
+	for _, component := range m.GetVisibleComponents() {
+		vOffset, hOffset := component.GetStackOffset()
+		output = utils.PlaceStacked(
+			output,
+			m.ViewComponent(component),
+			component.GetStackAnchor(),
+			vOffset,
+			hOffset,
+		)
+	}
+	return output
+}
+
+/*
+Returns a number that changes whenever the container's View would return something different:
+when its layout changes, or when one of its visible components would be rendered differently
+*/
+func (m LinearContainerModel) GetRenderRevision() uint64 {
+	hasher := con.NewRenderHasher().
+		AddInt(m.direction).
+		AddSize(m.GetFullContainerSize()).
+		AddInt(m.padding[:]...).
+		AddFloat(float64(m.getAlignmentPosition())).
+		AddString(m.renderGap(m.getContentMinorAxisSize()))
+	focused := m.GetFocusHandler().GetFocusedComponent()
 	for _, component := range m.GetVisibleComponents() {
+		hasher.AddComponent(component, component == focused)
+	}
+	return hasher.AddFocusHandler(m.GetFocusHandler()).Sum()
+}
+
+func (m LinearContainerModel) View() string {
+	// untouched containers (and the subtrees inside of them) aren't joined together again
+	return m.viewCache.Get(m.GetRenderRevision(), m.view)
+}
+
+func (m LinearContainerModel) view() (s string) {
+	if m.IsStacked() {
+		return con.PlaceFocusOverlay(m.viewStacked(), m.GetFocusHandler())
+	}
+	var views []string
+	gap := m.renderGap(m.getContentMinorAxisSize())
+	// Collect all the individual renderings for all the components (with the gaps between them)
+	for i, component := range m.GetVisibleComponents() {
+		if i > 0 && gap != "" {
+			views = append(views, gap)
+		}
 		views = append(views, m.ViewComponent(component))
 	}
 	// Join component renderings together
+	var output string
 	if m.IsHorizontal() {
-		return lipgloss.JoinHorizontal(
-			lipgloss.Center,
+		output = lipgloss.JoinHorizontal(
+			m.getAlignmentPosition(),
 			views...,
 		)
 	} else {
-		return lipgloss.JoinVertical(
-			lipgloss.Center,
+		output = lipgloss.JoinVertical(
+			m.getAlignmentPosition(),
 			views...,
 		)
 	}
+	top, right, bottom, left := m.GetPadding()
+	if top+right+bottom+left > 0 {
+		output = lipgloss.NewStyle().Padding(top, right, bottom, left).Render(output)
+	}
+	return con.PlaceFocusOverlay(output, m.GetFocusHandler())
 }
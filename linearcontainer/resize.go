@@ -0,0 +1,237 @@
+package linearcontainer
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	con "github.com/argotnaut/vanitea/container"
+	"github.com/argotnaut/vanitea/utils"
+)
+
+const (
+	GROW_PANE_KEY   = "alt+="
+	SHRINK_PANE_KEY = "alt+-"
+	RESET_PANE_KEY  = "alt+0"
+	// The default number of cells a pane grows or shrinks by with each resize action
+	DEFAULT_RESIZE_STEP = 2
+)
+
+/*
+Keeps track of a mouse drag that moves the boundary between two adjacent components
+*/
+type paneDrag struct {
+	// The component before the boundary being dragged
+	before *con.Component
+	// The component after the boundary being dragged
+	after *con.Component
+	// The position of the cursor along the major axis when the drag started
+	startPosition int
+	// The sizes of the two components along the major axis when the drag started
+	beforeSize int
+	afterSize  int
+	// The size hints of the two components when the drag started
+	beforeHint con.SizeHint
+	afterHint  con.SizeHint
+}
+
+/*
+Returns the number of cells a pane grows or shrinks by with each resize action
+*/
+func (m LinearContainerModel) GetResizeStep() int {
+	if m.resizeStep < 1 {
+		return DEFAULT_RESIZE_STEP
+	}
+	return m.resizeStep
+}
+
+/*
+Sets the number of cells a pane grows or shrinks by with each resize action
+*/
+func (m *LinearContainerModel) SetResizeStep(step int) *LinearContainerModel {
+	m.resizeStep = step
+	return m
+}
+
+/*
+Returns an Action that changes the size hint of the target component, and which restores the
+previous size hint when undone (the container is laid out again on the con.RelayoutMsg sent
+after the Action runs)
+*/
+func (m LinearContainerModel) newSizeHintAction(
+	name string,
+	description string,
+	shortcut string,
+	target *con.Component,
+	getNewHint func(*con.Component) con.SizeHint,
+) con.Action {
+	var previousHint con.SizeHint
+	return con.NewDefaultAction(
+		name,
+		description,
+		shortcut,
+		target,
+		func(component *con.Component) {
+			previousHint = component.GetSizeHint()
+			component.SetSizeHint(getNewHint(component))
+		},
+		func(component *con.Component) {
+			component.SetSizeHint(previousHint)
+		},
+	)
+}
+
+/*
+Returns the actions for resizing the focused component along the major axis. These
+are only provided by the container the focused component belongs to directly, so that
+nested containers don't offer conflicting actions for the same shortcuts
+*/
+func (m LinearContainerModel) getResizeActions() []con.Action {
+	focused := m.GetFocusHandler().GetFocusedComponent()
+	if m.IsStacked() || focused == nil || !slices.Contains(m.GetVisibleComponents(), focused) {
+		return nil
+	}
+	resizeBy := func(displacement int) func(*con.Component) con.SizeHint {
+		return func(component *con.Component) con.SizeHint {
+			currentSize := m.GetSizeAlongMajorAxis(component.GetSize())
+			return con.FixedSize(utils.ClampInt(
+				currentSize+displacement,
+				m.getMinimumSize(component),
+				m.getMaximumSize(component),
+			))
+		}
+	}
+	return []con.Action{
+		m.newSizeHintAction(
+			"grow pane",
+			"Grow the focused pane along the container's major axis",
+			GROW_PANE_KEY,
+			focused,
+			resizeBy(m.GetResizeStep()),
+		),
+		m.newSizeHintAction(
+			"shrink pane",
+			"Shrink the focused pane along the container's major axis",
+			SHRINK_PANE_KEY,
+			focused,
+			resizeBy(-m.GetResizeStep()),
+		),
+		m.newSizeHintAction(
+			"reset pane size",
+			"Let the container size the focused pane automatically",
+			RESET_PANE_KEY,
+			focused,
+			func(*con.Component) con.SizeHint { return con.AutoSize() },
+		),
+	}
+}
+
+/*
+Returns the pair of adjacent visible components whose shared boundary (their facing
+border cells and any gap between them) lies under the given point, if there is one
+*/
+func (m LinearContainerModel) getBoundaryAt(x int, y int) (before *con.Component, after *con.Component, ok bool) {
+	visible := m.GetVisibleComponents()
+	major, minor := x, y
+	if !m.IsHorizontal() {
+		major, minor = y, x
+	}
+	for i := 0; i+1 < len(visible); i++ {
+		beforeBounds, afterBounds := visible[i].GetBounds(), visible[i+1].GetBounds()
+		boundaryStart, boundaryEnd := beforeBounds.X+beforeBounds.Width-1, afterBounds.X
+		minorStart := min(beforeBounds.Y, afterBounds.Y)
+		minorEnd := max(beforeBounds.Y+beforeBounds.Height, afterBounds.Y+afterBounds.Height)
+		if !m.IsHorizontal() {
+			boundaryStart, boundaryEnd = beforeBounds.Y+beforeBounds.Height-1, afterBounds.Y
+			minorStart = min(beforeBounds.X, afterBounds.X)
+			minorEnd = max(beforeBounds.X+beforeBounds.Width, afterBounds.X+afterBounds.Width)
+		}
+		if major >= boundaryStart && major <= boundaryEnd && minor >= minorStart && minor < minorEnd {
+			return visible[i], visible[i+1], true
+		}
+	}
+	return nil, nil, false
+}
+
+/*
+Handles mouse messages that start, continue or finish dragging the boundary between two
+components. Returns whether the message was consumed by a drag. When a drag finishes, the
+resize is reported as an executed Action so that it can be undone
+*/
+func (m *LinearContainerModel) handlePaneDrag(msg tea.MouseMsg) (tea.Cmd, bool) {
+	if m.IsStacked() {
+		return nil, false
+	}
+	majorPosition := msg.X
+	if !m.IsHorizontal() {
+		majorPosition = msg.Y
+	}
+
+	switch {
+	case m.drag == nil && msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft:
+		before, after, ok := m.getBoundaryAt(msg.X, msg.Y)
+		if !ok {
+			return nil, false
+		}
+		m.drag = &paneDrag{
+			before:        before,
+			after:         after,
+			startPosition: majorPosition,
+			beforeSize:    m.GetSizeAlongMajorAxis(before.GetSize()),
+			afterSize:     m.GetSizeAlongMajorAxis(after.GetSize()),
+			beforeHint:    before.GetSizeHint(),
+			afterHint:     after.GetSizeHint(),
+		}
+		return nil, true
+	case m.drag != nil && msg.Action == tea.MouseActionMotion:
+		return m.dragBoundaryTo(majorPosition), true
+	case m.drag != nil && msg.Action == tea.MouseActionRelease:
+		cmd := m.dragBoundaryTo(majorPosition)
+		drag := *m.drag
+		m.drag = nil
+		if drag.before.GetSizeHint() == drag.beforeHint && drag.after.GetSizeHint() == drag.afterHint {
+			return cmd, true
+		}
+		return tea.Batch(cmd, con.ReportExecutedAction(m.newPaneDragAction(drag))), true
+	}
+	return nil, false
+}
+
+/*
+Moves the boundary of the current drag so that it follows the cursor, keeping the combined
+size of the two components the same and each of them within its minimum and maximum size
+*/
+func (m *LinearContainerModel) dragBoundaryTo(majorPosition int) tea.Cmd {
+	drag := m.drag
+	total := drag.beforeSize + drag.afterSize
+	newBeforeSize := utils.ClampInt(
+		drag.beforeSize+majorPosition-drag.startPosition,
+		max(m.getMinimumSize(drag.before), total-m.getMaximumSize(drag.after)),
+		min(m.getMaximumSize(drag.before), total-m.getMinimumSize(drag.after)),
+	)
+	drag.before.SetSizeHint(con.FixedSize(newBeforeSize))
+	drag.after.SetSizeHint(con.FixedSize(total - newBeforeSize))
+	return con.RelayoutContainer(m, m.size)
+}
+
+/*
+Returns an (already executed) Action representing the given finished drag, which
+restores both components' previous size hints when undone
+*/
+func (m LinearContainerModel) newPaneDragAction(drag paneDrag) con.Action {
+	beforeHint, afterHint := drag.before.GetSizeHint(), drag.after.GetSizeHint()
+	return con.NewDefaultAction(
+		"drag pane border",
+		"Resize two adjacent panes by dragging the border between them",
+		"",
+		drag.before,
+		func(*con.Component) {
+			drag.before.SetSizeHint(beforeHint)
+			drag.after.SetSizeHint(afterHint)
+		},
+		func(*con.Component) {
+			drag.before.SetSizeHint(drag.beforeHint)
+			drag.after.SetSizeHint(drag.afterHint)
+		},
+	)
+}
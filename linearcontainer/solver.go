@@ -0,0 +1,264 @@
+package linearcontainer
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	con "github.com/argotnaut/vanitea/container"
+	"github.com/argotnaut/vanitea/utils"
+)
+
+/*
+What the layout solver needs to know about a component to size it along the major axis
+*/
+type layoutItem struct {
+	// The size the component starts out at (its minimum size, or the exact size it asks for)
+	base int
+	// The largest size the component can grow to
+	max int
+	// The component's share of the leftover space relative to the other components' (0 if it doesn't grow)
+	weight int
+	// Which components get the cells that can't be split evenly by weight (the highest priorities first)
+	priority int
+	// How far the component can shrink below its base size when the components overflow (0 unless it asks for an exact size)
+	shrink int
+}
+
+/*
+Returns the size of each of the given items along the major axis, given the space available
+along it. Weights work like flex-grow in CSS: they share out the space that's left over once
+every item has its base size, rather than the whole axis, so the sizes of items with different
+base sizes aren't in proportion to their weights. The solver works in a single pass (after
+sorting the growable items):
+
+ 1. every item starts out at its base size. If that overflows the available space, the items
+    that can shrink are shrunk instead (see shrinkLayout) and nothing grows
+ 2. the space that's left over is shared between the items with a weight, in proportion to their weights
+ 3. the items whose share would take them past their maximum size get their maximum size instead, and
+    what they don't use is shared between the others (the items are visited in the order they'd reach
+    their maximum sizes, so each is only visited once)
+ 4. the cells that can't be split evenly by weight (fewer than the number of items sharing them) go
+    one each to the items with the highest priorities, then to the earlier items among equal priorities
+
+For example (the base, maximum, weight, priority and shrink of each item, and the space available):
+
+	items                                           available  sizes
+	(2, ∞, 1, 1, 0) (2, ∞, 1, 1, 0)                 10         5 5
+	(2, ∞, 1, 1, 0) (2, ∞, 1, 1, 0) (2, ∞, 1, 1, 0) 10         4 3 3
+	(2, ∞, 1, 1, 0) (2, ∞, 1, 1, 0) (2, ∞, 1, 2, 0) 10         3 3 4
+	(2, ∞, 1, 1, 0) (2, ∞, 3, 1, 0)                 10         4 6
+	(2, 3, 1, 1, 0) (2, ∞, 1, 1, 0)                 10         3 7
+	(2, ∞, 0, 1, 0) (2, ∞, 1, 1, 0)                 10         2 8
+	(6, 6, 0, 1, 4) (2, ∞, 1, 1, 0)                 10         6 4
+	(6, 6, 0, 1, 0) (6, ∞, 1, 1, 0)                 10         6 6
+	(6, 6, 0, 1, 4) (6, ∞, 1, 1, 0)                 10         4 6
+	(2, 4, 1, 1, 0) (2, 4, 1, 1, 0)                 10         4 4
+*/
+func solveLayout(items []layoutItem, available int) []int {
+	sizes := make([]int, len(items))
+	remaining := available
+	for i, item := range items {
+		sizes[i] = item.base
+		remaining -= item.base
+	}
+	if remaining < 0 {
+		shrinkLayout(items, sizes, -remaining)
+		return sizes
+	}
+	// how far each item can grow (no item can use more than all of the leftover space)
+	room := make([]int, len(items))
+	var growable []int
+	totalWeight := 0
+	for i, item := range items {
+		room[i] = min(max(0, item.max-item.base), max(0, remaining))
+		if item.weight > 0 && room[i] > 0 {
+			growable = append(growable, i)
+			totalWeight += item.weight
+		}
+	}
+	if remaining <= 0 || len(growable) == 0 {
+		return sizes
+	}
+	// visit the items in the order they'd reach their maximum sizes (the least room per unit of weight first)
+	slices.SortStableFunc(growable, func(a int, b int) int {
+		return cmp.Compare(room[a]*items[b].weight, room[b]*items[a].weight)
+	})
+	for len(growable) > 0 {
+		i := growable[0]
+		// once an item's share doesn't reach its maximum size, none of the following items' shares do either
+		if room[i]*totalWeight > remaining*items[i].weight {
+			break
+		}
+		sizes[i] += room[i]
+		remaining -= room[i]
+		totalWeight -= items[i].weight
+		growable = growable[1:]
+	}
+	// if every item reached its maximum size, the rest of the space is left empty
+	if len(growable) == 0 {
+		return sizes
+	}
+	distributed := 0
+	for _, i := range growable {
+		share := remaining * items[i].weight / totalWeight
+		sizes[i] += share
+		distributed += share
+	}
+	// none of these items reach their maximum sizes, so each of them has room for another cell
+	slices.SortStableFunc(growable, func(a int, b int) int {
+		if items[a].priority != items[b].priority {
+			return cmp.Compare(items[b].priority, items[a].priority)
+		}
+		return cmp.Compare(a, b)
+	})
+	for _, i := range growable[:remaining-distributed] {
+		sizes[i]++
+	}
+	return sizes
+}
+
+/*
+Shrinks the given sizes (the items' base sizes) by the given overflow, taking it from the items that
+can shrink in proportion to how far each of them can. The cells that can't be split evenly are taken
+one each from the items with the lowest priorities, then from the later items among equal priorities.
+Items don't shrink further than they can, so the sizes still overflow if they can't shrink far enough
+*/
+func shrinkLayout(items []layoutItem, sizes []int, overflow int) {
+	var shrinkable []int
+	totalShrink := 0
+	for i, item := range items {
+		if item.shrink > 0 {
+			shrinkable = append(shrinkable, i)
+			totalShrink += item.shrink
+		}
+	}
+	if totalShrink <= overflow {
+		for _, i := range shrinkable {
+			sizes[i] -= items[i].shrink
+		}
+		return
+	}
+	taken := 0
+	for _, i := range shrinkable {
+		share := overflow * items[i].shrink / totalShrink
+		sizes[i] -= share
+		taken += share
+	}
+	// none of these items shrink as far as they can, so each of them can give up another cell
+	slices.SortStableFunc(shrinkable, func(a int, b int) int {
+		if items[a].priority != items[b].priority {
+			return cmp.Compare(items[a].priority, items[b].priority)
+		}
+		return cmp.Compare(b, a)
+	})
+	for _, i := range shrinkable[:overflow-taken] {
+		sizes[i]--
+	}
+}
+
+/*
+Returns what the layout solver needs to know about the given component, given the space
+available to the LinearContainerModel's components
+*/
+func (m LinearContainerModel) getLayoutItem(component *con.Component, containerSize tea.WindowSizeMsg) layoutItem {
+	switch {
+	case component.IsHidden():
+		return layoutItem{}
+	case component.IsCollapsed():
+		// collapsed components get exactly one row or column for their border line
+		return layoutItem{base: 1, max: 1}
+	}
+	minimumSize, maximumSize := component.GetLayoutBounds(m.GetComponentStyle(component))
+	minimum, maximum := m.GetSizeAlongMajorAxis(minimumSize), m.GetSizeAlongMajorAxis(maximumSize)
+	hint := m.getSizeHint(*component)
+	target := minimum
+	if hint.IsExact() {
+		target = hint.Resolve(m.GetSizeAlongMajorAxis(containerSize))
+	}
+	if component.ShrinkToContent() {
+		// the component doesn't grow beyond the space its content takes up when it's given all of the space
+		size := containerSize
+		m.SetMajorAndMinorAxes(
+			&size,
+			min(maximum, m.GetSizeAlongMajorAxis(containerSize)),
+			m.getMinorAxisSizeForComponent(component, m.GetSizeAlongMinorAxis(containerSize)),
+		)
+		contentSize := m.getContentSize(component, size)
+		maximum = utils.ClampInt(
+			min(m.GetSizeAlongMajorAxis(contentSize.Preferred), m.GetSizeAlongMajorAxis(contentSize.Maximum)),
+			minimum,
+			maximum,
+		)
+	}
+	item := layoutItem{
+		base:     utils.ClampInt(target, minimum, maximum),
+		max:      maximum,
+		priority: component.GetPriority(),
+	}
+	// components with exact sizes never grow, but they shrink (as far as their minimum sizes) when the components overflow
+	if hint.IsExact() {
+		item.shrink = item.base - minimum
+	} else {
+		item.weight = hint.GetWeight()
+	}
+	return item
+}
+
+/*
+Returns the size the LinearContainerModel's components take up within the given constraint. Its
+smallest size is the one where each of its visible components is at its smallest size (see
+con.Component.GetLayoutBounds), and its largest is the one where each of them is at its largest
+(plus the padding and gaps around and between them). Containers laying out the LinearContainerModel's
+component take these into account, so its components don't get crushed or overflow
+*/
+func (m LinearContainerModel) GetContentSize(constraint tea.WindowSizeMsg) con.ContentSize {
+	var minimumMajor, minimumMinor, maximumMajor, maximumMinor int
+	components := m.GetVisibleComponents()
+	for _, component := range components {
+		minimum, maximum := component.GetLayoutBounds(m.GetComponentStyle(component))
+		if component.IsCollapsed() && !m.IsStacked() {
+			// collapsed components only take up one row or column for their border line
+			m.SetMajorAndMinorAxes(&minimum, 1, 0)
+			m.SetMajorAndMinorAxes(&maximum, 1, 0)
+		}
+		if m.IsStacked() {
+			// stacked components don't share space
+			minimumMajor = max(minimumMajor, m.GetSizeAlongMajorAxis(minimum))
+			maximumMajor = max(maximumMajor, m.GetSizeAlongMajorAxis(maximum))
+		} else {
+			minimumMajor = utils.SaturatingSum(minimumMajor, m.GetSizeAlongMajorAxis(minimum))
+			maximumMajor = utils.SaturatingSum(maximumMajor, m.GetSizeAlongMajorAxis(maximum))
+		}
+		minimumMinor = max(minimumMinor, m.GetSizeAlongMinorAxis(minimum))
+		maximumMinor = max(maximumMinor, m.GetSizeAlongMinorAxis(maximum))
+	}
+	// an empty container doesn't limit how much space it can be given
+	if len(components) == 0 {
+		maximumMajor, maximumMinor = math.MaxInt, math.MaxInt
+	}
+	// stretched components grow past their maximum sizes along the minor axis
+	if m.GetAlignment() == ALIGN_STRETCH && !m.IsStacked() {
+		maximumMinor = math.MaxInt
+	}
+	var minimum, maximum tea.WindowSizeMsg
+	if m.IsStacked() {
+		m.SetMajorAndMinorAxes(&minimum, minimumMajor, minimumMinor)
+		m.SetMajorAndMinorAxes(&maximum, maximumMajor, maximumMinor)
+	} else {
+		majorSpacing, minorSpacing := m.getMajorAxisSpacing(), m.getMinorAxisSpacing()
+		m.SetMajorAndMinorAxes(&minimum, minimumMajor+majorSpacing, minimumMinor+minorSpacing)
+		m.SetMajorAndMinorAxes(
+			&maximum,
+			utils.SaturatingSum(maximumMajor, majorSpacing),
+			utils.SaturatingSum(maximumMinor, minorSpacing),
+		)
+	}
+	return con.ContentSize{
+		Preferred: tea.WindowSizeMsg{Width: min(constraint.Width, maximum.Width), Height: min(constraint.Height, maximum.Height)},
+		Minimum:   minimum,
+		Maximum:   maximum,
+	}
+}
@@ -0,0 +1,280 @@
+package linearcontainer
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	con "github.com/argotnaut/vanitea/container"
+)
+
+const UNBOUNDED = math.MaxInt
+
+func TestSolveLayout(t *testing.T) {
+	cases := []struct {
+		name      string
+		items     []layoutItem
+		available int
+		want      []int
+	}{
+		{
+			name:      "no items",
+			items:     nil,
+			available: 10,
+			want:      []int{},
+		},
+		{
+			name:      "even split",
+			items:     []layoutItem{{2, UNBOUNDED, 1, 1, 0}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{5, 5},
+		},
+		{
+			name:      "remainder goes to the earlier items",
+			items:     []layoutItem{{2, UNBOUNDED, 1, 1, 0}, {2, UNBOUNDED, 1, 1, 0}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{4, 3, 3},
+		},
+		{
+			// the old grow loop sorted by slice position instead of priority, so it gave the remainder to the first item
+			name:      "remainder goes to the highest priority",
+			items:     []layoutItem{{2, UNBOUNDED, 1, 1, 0}, {2, UNBOUNDED, 1, 1, 0}, {2, UNBOUNDED, 1, 2, 0}},
+			available: 10,
+			want:      []int{3, 3, 4},
+		},
+		{
+			name:      "remainder goes to the highest priorities first, then the earlier items",
+			items:     []layoutItem{{0, UNBOUNDED, 1, 1, 0}, {0, UNBOUNDED, 1, 3, 0}, {0, UNBOUNDED, 1, 1, 0}, {0, UNBOUNDED, 1, 3, 0}},
+			available: 10,
+			want:      []int{2, 3, 2, 3},
+		},
+		{
+			name:      "weighted",
+			items:     []layoutItem{{2, UNBOUNDED, 1, 1, 0}, {2, UNBOUNDED, 3, 1, 0}},
+			available: 10,
+			want:      []int{4, 6},
+		},
+		{
+			name:      "weighted without a remainder",
+			items:     []layoutItem{{0, UNBOUNDED, 1, 1, 0}, {0, UNBOUNDED, 2, 1, 0}, {0, UNBOUNDED, 1, 1, 0}},
+			available: 12,
+			want:      []int{3, 6, 3},
+		},
+		{
+			name:      "maximum size",
+			items:     []layoutItem{{2, 3, 1, 1, 0}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{3, 7},
+		},
+		{
+			name:      "maximum sizes reached one after another",
+			items:     []layoutItem{{0, 2, 1, 1, 0}, {0, 3, 1, 1, 0}, {0, UNBOUNDED, 1, 1, 0}},
+			available: 12,
+			want:      []int{2, 3, 7},
+		},
+		{
+			name:      "every item reaches its maximum size",
+			items:     []layoutItem{{2, 4, 1, 1, 0}, {2, 4, 1, 1, 0}},
+			available: 10,
+			want:      []int{4, 4},
+		},
+		{
+			name:      "weightless items don't grow",
+			items:     []layoutItem{{2, UNBOUNDED, 0, 1, 0}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{2, 8},
+		},
+		{
+			name:      "fixed size",
+			items:     []layoutItem{{6, 6, 0, 1, 0}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{6, 4},
+		},
+		{
+			name:      "overflow keeps the base sizes of items that can't shrink",
+			items:     []layoutItem{{6, 6, 0, 1, 0}, {6, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{6, 6},
+		},
+		{
+			name:      "overflow shrinks the items that can shrink",
+			items:     []layoutItem{{6, 6, 0, 1, 4}, {6, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{4, 6},
+		},
+		{
+			name:      "no space",
+			items:     []layoutItem{{2, UNBOUNDED, 1, 1, 0}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 0,
+			want:      []int{2, 2},
+		},
+		{
+			name:      "hidden items take no space",
+			items:     []layoutItem{{}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{0, 10},
+		},
+		{
+			name:      "percent size",
+			items:     []layoutItem{{5, UNBOUNDED, 0, 1, 5}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{5, 5},
+		},
+		{
+			name:      "collapsed items take one cell",
+			items:     []layoutItem{{1, 1, 0, 0, 0}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{1, 9},
+		},
+		{
+			name:      "hidden and collapsed items",
+			items:     []layoutItem{{}, {1, 1, 0, 0, 0}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{0, 1, 9},
+		},
+		{
+			name:      "overflow is taken in proportion to how far the items can shrink",
+			items:     []layoutItem{{8, 8, 0, 1, 6}, {4, 4, 0, 1, 2}, {2, UNBOUNDED, 1, 1, 0}},
+			available: 10,
+			want:      []int{5, 3, 2},
+		},
+		{
+			name:      "overflowing percent and fixed sizes",
+			items:     []layoutItem{{8, UNBOUNDED, 0, 1, 8}, {6, 6, 0, 1, 6}},
+			available: 10,
+			want:      []int{6, 4},
+		},
+		{
+			name:      "overflow remainder is taken from the lowest priorities, then the later items",
+			items:     []layoutItem{{5, 5, 0, 1, 5}, {5, 5, 0, 2, 5}, {5, 5, 0, 1, 5}},
+			available: 14,
+			want:      []int{5, 5, 4},
+		},
+		{
+			name:      "overflow past what the items can shrink",
+			items:     []layoutItem{{6, 6, 0, 1, 2}, {6, UNBOUNDED, 1, 1, 0}},
+			available: 5,
+			want:      []int{4, 6},
+		},
+		{
+			name:      "overflow with collapsed and hidden items",
+			items:     []layoutItem{{}, {1, 1, 0, 0, 0}, {6, 6, 0, 1, 4}, {4, UNBOUNDED, 1, 1, 0}},
+			available: 8,
+			want:      []int{0, 1, 3, 4},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := solveLayout(c.items, c.available); !slices.Equal(got, c.want) {
+				t.Errorf("solveLayout(%v, %d) = %v, want %v", c.items, c.available, got, c.want)
+			}
+		})
+	}
+}
+
+type sizedModel struct{}
+
+func (m sizedModel) Init() tea.Cmd                       { return nil }
+func (m sizedModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (m sizedModel) View() string                        { return "" }
+
+func TestSolveLayoutForComponents(t *testing.T) {
+	newComponent := func(hint con.SizeHint) *con.Component {
+		return con.ComponentFromModel(sizedModel{}).SetSizeHint(hint)
+	}
+	auto := func() *con.Component { return newComponent(con.AutoSize()) }
+	cases := []struct {
+		name       string
+		components []*con.Component
+		width      int
+		want       []int
+	}{
+		{
+			name:       "fixed",
+			components: []*con.Component{newComponent(con.FixedSize(5)), auto()},
+			width:      20,
+			want:       []int{5, 15},
+		},
+		{
+			name:       "percent",
+			components: []*con.Component{newComponent(con.PercentSize(25)), auto()},
+			width:      20,
+			want:       []int{5, 15},
+		},
+		{
+			name:       "percent rounds down",
+			components: []*con.Component{newComponent(con.PercentSize(33)), auto()},
+			width:      10,
+			want:       []int{3, 7},
+		},
+		{
+			name:       "weighted",
+			components: []*con.Component{newComponent(con.WeightedSize(1)), newComponent(con.WeightedSize(3))},
+			width:      20,
+			want:       []int{6, 14},
+		},
+		{
+			name:       "fixed size below the minimum",
+			components: []*con.Component{newComponent(con.FixedSize(1)).SetMinimumWidth(4), auto()},
+			width:      20,
+			want:       []int{4, 16},
+		},
+		{
+			name:       "fixed size above the maximum",
+			components: []*con.Component{newComponent(con.FixedSize(30)).SetMaximumWidth(8), auto()},
+			width:      20,
+			want:       []int{8, 12},
+		},
+		{
+			name:       "maximum size",
+			components: []*con.Component{auto().SetMaximumWidth(5), auto()},
+			width:      20,
+			want:       []int{5, 15},
+		},
+		{
+			name:       "minimum size",
+			components: []*con.Component{auto().SetMinimumWidth(12), auto()},
+			width:      20,
+			want:       []int{15, 5},
+		},
+		{
+			name:       "priority",
+			components: []*con.Component{auto(), auto(), auto().SetPriority(3)},
+			width:      10,
+			want:       []int{3, 3, 4},
+		},
+		{
+			name:       "overflowing fixed sizes shrink",
+			components: []*con.Component{newComponent(con.FixedSize(12)), newComponent(con.FixedSize(12))},
+			width:      20,
+			want:       []int{10, 10},
+		},
+		{
+			name:       "hidden",
+			components: []*con.Component{auto().SetHidden(true), auto()},
+			width:      20,
+			want:       []int{0, 20},
+		},
+		{
+			name:       "collapsed",
+			components: []*con.Component{auto().SetCollapsed(true), auto()},
+			width:      20,
+			want:       []int{1, 19},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := NewLinearContainerFromComponents(c.components)
+			size := tea.WindowSizeMsg{Width: c.width, Height: 5}
+			var items []layoutItem
+			for _, component := range m.GetComponents() {
+				items = append(items, m.getLayoutItem(component, size))
+			}
+			if got := solveLayout(items, c.width); !slices.Equal(got, c.want) {
+				t.Errorf("solveLayout(%v, %d) = %v, want %v", items, c.width, got, c.want)
+			}
+		})
+	}
+}
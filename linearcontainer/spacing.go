@@ -0,0 +1,220 @@
+package linearcontainer
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	con "github.com/argotnaut/vanitea/container"
+	"github.com/argotnaut/vanitea/utils"
+)
+
+const (
+	// Centers components along the minor axis
+	ALIGN_CENTER int = iota
+	// Aligns components to the top (or left) of the minor axis
+	ALIGN_START
+	// Aligns components to the bottom (or right) of the minor axis
+	ALIGN_END
+	// Grows components to fill the minor axis, even beyond their maximum size along that axis
+	ALIGN_STRETCH
+)
+
+/*
+Returns the number of empty cells left between adjacent components
+*/
+func (m LinearContainerModel) GetGap() int {
+	return m.gap
+}
+
+/*
+Sets the number of empty cells left between adjacent components
+*/
+func (m *LinearContainerModel) SetGap(gap int) *LinearContainerModel {
+	m.gap = max(0, gap)
+	return m
+}
+
+/*
+Returns the number of empty cells between the edges of the LinearContainerModel and its components
+*/
+func (m LinearContainerModel) GetPadding() (top int, right int, bottom int, left int) {
+	return m.padding[0], m.padding[1], m.padding[2], m.padding[3]
+}
+
+/*
+Sets the number of empty cells between the edges of the LinearContainerModel and its components
+*/
+func (m *LinearContainerModel) SetPadding(top int, right int, bottom int, left int) *LinearContainerModel {
+	m.padding = [4]int{max(0, top), max(0, right), max(0, bottom), max(0, left)}
+	return m
+}
+
+/*
+Returns how components are aligned along the minor axis (ALIGN_CENTER, ALIGN_START, ALIGN_END or ALIGN_STRETCH)
+*/
+func (m LinearContainerModel) GetAlignment() int {
+	return m.alignment
+}
+
+/*
+Sets how components are aligned along the minor axis (ALIGN_CENTER, ALIGN_START, ALIGN_END or ALIGN_STRETCH)
+*/
+func (m *LinearContainerModel) SetAlignment(alignment int) *LinearContainerModel {
+	m.alignment = alignment
+	return m
+}
+
+/*
+Returns whether a separator line is drawn between adjacent components
+*/
+func (m LinearContainerModel) IsShowingSeparators() bool {
+	return m.showSeparators
+}
+
+/*
+Sets whether a separator line is drawn between adjacent components (separators
+need at least one cell, so the gap is widened to one cell if it's smaller)
+*/
+func (m *LinearContainerModel) SetShowSeparators(showSeparators bool) *LinearContainerModel {
+	m.showSeparators = showSeparators
+	return m
+}
+
+/*
+Returns the style whose border characters and border color are used to draw separators
+*/
+func (m LinearContainerModel) GetSeparatorStyle() lipgloss.Style {
+	return m.separatorStyle
+}
+
+/*
+Sets the style whose border characters and border color are used to draw separators
+(using the same style as the components' borders makes the separators match them)
+*/
+func (m *LinearContainerModel) SetSeparatorStyle(style lipgloss.Style) *LinearContainerModel {
+	m.separatorStyle = style
+	return m
+}
+
+/*
+Returns the number of cells between adjacent components, including the separator (if shown)
+*/
+func (m LinearContainerModel) getGapSize() int {
+	if m.IsShowingSeparators() {
+		return max(1, m.GetGap())
+	}
+	return m.GetGap()
+}
+
+/*
+Returns the padding before and after the components along the major axis
+*/
+func (m LinearContainerModel) getMajorAxisPadding() (before int, after int) {
+	top, right, bottom, left := m.GetPadding()
+	if m.IsHorizontal() {
+		return left, right
+	}
+	return top, bottom
+}
+
+/*
+Returns the padding before and after the components along the minor axis
+*/
+func (m LinearContainerModel) getMinorAxisPadding() (before int, after int) {
+	top, right, bottom, left := m.GetPadding()
+	if m.IsHorizontal() {
+		return top, bottom
+	}
+	return left, right
+}
+
+/*
+Returns the total amount of space along the major axis taken up by padding and gaps
+*/
+func (m LinearContainerModel) getMajorAxisSpacing() int {
+	before, after := m.getMajorAxisPadding()
+	return before + after + max(0, len(m.GetVisibleComponents())-1)*m.getGapSize()
+}
+
+/*
+Returns the total amount of space along the minor axis taken up by padding
+*/
+func (m LinearContainerModel) getMinorAxisSpacing() int {
+	before, after := m.getMinorAxisPadding()
+	return before + after
+}
+
+/*
+Returns the area available to the components once padding and gaps are taken out of the given size
+*/
+func (m LinearContainerModel) getInnerSize(containerSize tea.WindowSizeMsg) tea.WindowSizeMsg {
+	m.SetMajorAndMinorAxes(
+		&containerSize,
+		max(0, m.GetSizeAlongMajorAxis(containerSize)-m.getMajorAxisSpacing()),
+		max(0, m.GetSizeAlongMinorAxis(containerSize)-m.getMinorAxisSpacing()),
+	)
+	return containerSize
+}
+
+/*
+Returns the size of the given component along the minor axis, given the space available along that axis
+*/
+func (m LinearContainerModel) getMinorAxisSizeForComponent(component *con.Component, available int) int {
+	minimum, maximum := component.GetLayoutBounds(m.GetComponentStyle(component))
+	if m.GetAlignment() == ALIGN_STRETCH {
+		return max(m.GetSizeAlongMinorAxis(minimum), available)
+	}
+	return utils.ClampInt(available, m.GetSizeAlongMinorAxis(minimum), m.GetSizeAlongMinorAxis(maximum))
+}
+
+/*
+Returns the lipgloss.Position used to join components along the minor axis
+*/
+func (m LinearContainerModel) getAlignmentPosition() lipgloss.Position {
+	switch m.GetAlignment() {
+	case ALIGN_START, ALIGN_STRETCH:
+		return lipgloss.Top
+	case ALIGN_END:
+		return lipgloss.Bottom
+	}
+	return lipgloss.Center
+}
+
+/*
+Returns the rendering of the space between two adjacent components, which
+spans the given size along the minor axis and contains a separator line in
+its middle (if separators are shown)
+*/
+func (m LinearContainerModel) renderGap(minorAxisSize int) string {
+	gapSize := m.getGapSize()
+	if gapSize < 1 || minorAxisSize < 1 {
+		return ""
+	}
+	border := m.GetSeparatorStyle().GetBorderStyle()
+	lineStyle := lipgloss.NewStyle().Foreground(m.GetSeparatorStyle().GetBorderTopForeground())
+	before := (gapSize - 1) / 2
+	after := gapSize - before - 1
+
+	var lines []string
+	if m.IsHorizontal() {
+		line := strings.Repeat(" ", gapSize)
+		if m.IsShowingSeparators() {
+			line = strings.Repeat(" ", before) + lineStyle.Render(border.Left) + strings.Repeat(" ", after)
+		}
+		for range minorAxisSize {
+			lines = append(lines, line)
+		}
+	} else {
+		blank := strings.Repeat(" ", minorAxisSize)
+		for i := range gapSize {
+			if m.IsShowingSeparators() && i == before {
+				lines = append(lines, lineStyle.Render(strings.Repeat(border.Top, minorAxisSize)))
+			} else {
+				lines = append(lines, blank)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,144 @@
+package tabcontainer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	con "github.com/argotnaut/vanitea/container"
+)
+
+const (
+	NEXT_TAB_KEY       = "ctrl+pgdown"
+	PREVIOUS_TAB_KEY   = "ctrl+pgup"
+	MOVE_TAB_RIGHT_KEY = "alt+pgdown"
+	MOVE_TAB_LEFT_KEY  = "alt+pgup"
+)
+
+/*
+Queues the given change for the latest copy of the TabContainerModel (see con.ChangeQueue), which
+Actions act on since the copy they were made from may be out of date by the time they're executed
+or undone
+*/
+func (m TabContainerModel) queueChange(change func(latest *TabContainerModel)) {
+	m.changes.Queue(func(container con.Container) {
+		if latest, isTab := container.(*TabContainerModel); isTab {
+			change(latest)
+		}
+	})
+}
+
+/*
+Returns an Action that switches to the tab returned by getTarget (which is given the latest copy
+of the TabContainerModel when the Action is executed), and which switches back to the previously
+active tab when undone
+*/
+func (m TabContainerModel) newSelectTabAction(
+	name string,
+	description string,
+	shortcut string,
+	getTarget func(latest TabContainerModel) *con.Component,
+) con.Action {
+	var previous *con.Component
+	return con.NewDefaultAction(
+		name,
+		description,
+		shortcut,
+		nil,
+		func(*con.Component) {
+			m.queueChange(func(latest *TabContainerModel) {
+				previous = latest.GetActiveTab()
+				*latest = latest.SelectTab(getTarget(*latest))
+			})
+		},
+		func(*con.Component) {
+			m.queueChange(func(latest *TabContainerModel) {
+				*latest = latest.SelectTab(previous)
+			})
+		},
+	).SetHandledAsKey(true) // the TabContainerModel handles the shortcuts as keys (see getTabKeyAction)
+}
+
+/*
+Returns an Action that moves the active tab by the given displacement in the tab
+strip, and which moves it back when undone
+*/
+func (m TabContainerModel) newMoveTabAction(name string, description string, shortcut string, displacement int) con.Action {
+	var moved *con.Component
+	var previousIdx int
+	return con.NewDefaultAction(
+		name,
+		description,
+		shortcut,
+		nil,
+		func(*con.Component) {
+			m.queueChange(func(latest *TabContainerModel) {
+				moved, previousIdx = latest.GetActiveTab(), latest.GetActiveTabIndex()
+				*latest = latest.MoveTab(moved, previousIdx+displacement)
+			})
+		},
+		func(*con.Component) {
+			m.queueChange(func(latest *TabContainerModel) {
+				*latest = latest.MoveTab(moved, previousIdx)
+			})
+		},
+	).SetHandledAsKey(true)
+}
+
+/*
+Returns the actions for switching between tabs and reordering them, including an
+action for switching to each tab that has a shortcut (using that shortcut)
+*/
+func (m TabContainerModel) getTabActions() (output []con.Action) {
+	if len(m.components) < 2 {
+		return nil
+	}
+	output = append(output,
+		m.newSelectTabAction(
+			"next tab",
+			"Switch to the tab to the right of the active tab",
+			NEXT_TAB_KEY,
+			func(latest TabContainerModel) *con.Component { return latest.getNeighborTab(1) },
+		),
+		m.newSelectTabAction(
+			"previous tab",
+			"Switch to the tab to the left of the active tab",
+			PREVIOUS_TAB_KEY,
+			func(latest TabContainerModel) *con.Component { return latest.getNeighborTab(-1) },
+		),
+		m.newMoveTabAction("move tab right", "Move the active tab one place to the right", MOVE_TAB_RIGHT_KEY, 1),
+		m.newMoveTabAction("move tab left", "Move the active tab one place to the left", MOVE_TAB_LEFT_KEY, -1),
+	)
+	for idx, component := range m.components {
+		shortcut := strings.TrimSpace(component.GetShortcut())
+		if shortcut == "" || component.IsHidden() {
+			continue
+		}
+		title := m.getTabTitle(idx)
+		output = append(output, m.newSelectTabAction(
+			fmt.Sprintf("show %s", title),
+			fmt.Sprintf("Switch to the %s tab", title),
+			shortcut,
+			func(TabContainerModel) *con.Component { return component },
+		))
+	}
+	return output
+}
+
+/*
+Returns the tab action (see getTabActions) whose shortcut is the given key, or nil if there isn't one.
+Keys that the active tab's actions use (e.g. those of a nested TabContainerModel) are left to the
+active tab, so that only the innermost TabContainerModel responds to them
+*/
+func (m TabContainerModel) getTabKeyAction(key string) con.Action {
+	usesKey := func(action con.Action) bool { return key != "" && action.GetShortcut() == key }
+	if active := m.GetActiveTab(); active != nil && slices.ContainsFunc(active.GetActions(), usesKey) {
+		return nil
+	}
+	for _, action := range m.getTabActions() {
+		if usesKey(action) {
+			return action
+		}
+	}
+	return nil
+}
@@ -0,0 +1,525 @@
+package tabcontainer
+
+import (
+	"math"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	con "github.com/argotnaut/vanitea/container"
+	"github.com/argotnaut/vanitea/utils"
+)
+
+/*
+A container that shows one of its components (the active tab) at a time, below a strip
+of tab labels made from its components' titles and shortcuts. Only the active tab is among
+its visible components, so the focus helpers skip the inactive tabs when walking into it.
+Hidden tabs are left out of the tab strip, and can't be switched to
+*/
+type TabContainerModel struct {
+	focusHandler con.FocusHandler
+	// The tabs, in the order their labels appear in the tab strip
+	components []*con.Component
+	// The index of the active tab, or -1 if there are no tabs (shared by copies of it)
+	active *int
+	// The area available to the TabContainerModel (including the tab strip)
+	size tea.WindowSizeMsg
+	// The TabContainerModel's last rendering (shared by copies of it)
+	viewCache *con.RenderCache
+	// The changes the TabContainerModel's Actions queued up for it (shared by copies of it)
+	changes *con.ChangeQueue
+}
+
+/*
+Instantiates an empty TabContainerModel
+*/
+func NewTabContainer() *TabContainerModel {
+	active := -1
+	tc := TabContainerModel{viewCache: &con.RenderCache{}, changes: &con.ChangeQueue{}, active: &active}
+	tc.SetFocusHandler(con.NewDefaultLinearFocusHandler(tc.GetComponents))
+	return &tc
+}
+
+/*
+Instantiates a TabContainerModel with the given components as its tabs (the first one is active)
+*/
+func NewTabContainerFromComponents(components []*con.Component) *TabContainerModel {
+	tc := NewTabContainer()
+	for _, component := range components {
+		tc.AddComponent(component)
+	}
+	return tc
+}
+
+/*
+Adds the given component as the last tab. It only becomes the active tab if there wasn't one
+*/
+func (m *TabContainerModel) AddComponent(component *con.Component) *TabContainerModel {
+	return m.InsertComponent(component, len(m.components))
+}
+
+/*
+Inserts the given component as a tab at the given index (appending it if the index is negative
+or past the end). It only becomes the active tab if there wasn't one
+*/
+func (m *TabContainerModel) InsertComponent(component *con.Component, idx int) *TabContainerModel {
+	if component == nil || slices.Contains(m.components, component) {
+		return m
+	}
+	if idx < 0 || idx > len(m.components) {
+		idx = len(m.components)
+	}
+	m.components = con.Splice(m.components, idx, idx, component)
+	if activeIdx := *m.active; activeIdx < 0 || idx <= activeIdx {
+		// the active tab stays active, wherever it was moved to
+		*m.active = activeIdx + 1
+	}
+	m.ensureVisibleTab()
+	m.SetFocusHandler(m.GetFocusHandler())
+	m.ensureValidFocus()
+	return m
+}
+
+/*
+Removes the given tab, returning the tea.Cmd from unmounting it (see con.Component.Unmount).
+If it was the active tab, the tab that took its place (or the one before it, if it was the
+last tab) becomes active
+*/
+func (m *TabContainerModel) RemoveComponent(component *con.Component) tea.Cmd {
+	idx := slices.Index(m.components, component)
+	if idx < 0 {
+		return nil
+	}
+	cmd := con.RemoveComponent(m, component, func() {
+		m.components = con.Splice(m.components, idx, idx+1)
+		// the tab that takes the active tab's place becomes active (or the one before it if it was the last tab)
+		if activeIdx := *m.active; idx < activeIdx || activeIdx >= len(m.components) {
+			*m.active = activeIdx - 1
+		}
+		m.ensureVisibleTab()
+	})
+	m.ensureValidFocus()
+	return cmd
+}
+
+/*
+Puts the given replacement in the place of the given tab (which is removed as by RemoveComponent),
+returning the tea.Cmd from unmounting the replaced tab. If the replaced tab was active, the
+replacement becomes the active tab
+*/
+func (m *TabContainerModel) ReplaceComponent(component *con.Component, replacement *con.Component) tea.Cmd {
+	idx := slices.Index(m.components, component)
+	if idx < 0 || replacement == nil {
+		return nil
+	}
+	wasActive := component == m.GetActiveTab()
+	cmd := con.ReplaceComponent(m, component, replacement, func() tea.Cmd {
+		m.InsertComponent(replacement, idx)
+		cmd := m.RemoveComponent(component)
+		if wasActive {
+			*m = m.SelectTab(replacement)
+		}
+		return cmd
+	})
+	m.ensureValidFocus()
+	return cmd
+}
+
+/*
+Returns the tab with the given ID, or nil if there isn't one
+*/
+func (m TabContainerModel) getTabByID(id string) *con.Component {
+	for _, component := range m.components {
+		if id != "" && component.GetID() == id {
+			return component
+		}
+	}
+	return nil
+}
+
+/*
+Changes the TabContainerModel's tabs as the given message describes (if it targets this
+container, otherwise it's passed on to the nested container it targets) and lays them out again
+*/
+func (m *TabContainerModel) handleComponentsMsg(msg con.ComponentsMsg) tea.Cmd {
+	if msg.GetContainerID() != "" {
+		cmd, handler := con.ForwardComponentsMsg(m.GetComponents(), m.GetFocusHandler(), msg)
+		m.SetFocusHandler(handler)
+		m.ensureValidFocus()
+		return cmd
+	}
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case con.InsertComponentMsg:
+		m.InsertComponent(msg.Component, msg.Index)
+	case con.RemoveComponentMsg:
+		cmd = m.RemoveComponent(m.getTabByID(msg.ComponentID))
+	case con.ReplaceComponentMsg:
+		cmd = m.ReplaceComponent(m.getTabByID(msg.ComponentID), msg.Component)
+	case con.MoveComponentMsg:
+		if component := m.getTabByID(msg.ComponentID); component != nil {
+			*m = m.MoveTab(component, msg.Index)
+		}
+	}
+	return tea.Batch(cmd, con.RelayoutContainer(m, m.size))
+}
+
+func (m TabContainerModel) GetComponent(idx int) *con.Component {
+	if idx < 0 || idx >= len(m.components) {
+		return nil
+	}
+	return m.components[idx]
+}
+
+func (m TabContainerModel) GetComponents() []*con.Component {
+	return m.components
+}
+
+/*
+Returns the active tab, which is the only one of the components that's visible (unless it's hidden)
+*/
+func (m TabContainerModel) GetVisibleComponents() (output []*con.Component) {
+	if active := m.GetActiveTab(); active != nil && !active.IsHidden() {
+		output = append(output, active)
+	}
+	return
+}
+
+/*
+Returns the component of the active tab, or nil if there are no tabs
+*/
+func (m TabContainerModel) GetActiveTab() *con.Component {
+	return m.GetComponent(m.GetActiveTabIndex())
+}
+
+/*
+Returns the index of the active tab, or -1 if there are no tabs
+*/
+func (m TabContainerModel) GetActiveTabIndex() int {
+	if m.active == nil || *m.active >= len(m.components) {
+		return -1
+	}
+	return *m.active
+}
+
+/*
+Makes the given component the active tab (if it's one of the tabs and isn't hidden), which is
+sized to fill the area below the tab strip the next time the TabContainerModel is laid out
+*/
+func (m TabContainerModel) SelectTab(target *con.Component) TabContainerModel {
+	idx := slices.Index(m.components, target)
+	if idx < 0 || target.IsHidden() {
+		return m
+	}
+	*m.active = idx
+	return m
+}
+
+/*
+Makes the tab at the given index the active tab (wrapping around at either end)
+*/
+func (m TabContainerModel) SelectTabIndex(idx int) TabContainerModel {
+	if len(m.components) < 1 {
+		return m
+	}
+	idx %= len(m.components)
+	if idx < 0 {
+		idx += len(m.components)
+	}
+	return m.SelectTab(m.components[idx])
+}
+
+/*
+Returns the closest tab that isn't hidden in the given direction (1 for the right, -1 for
+the left) from the active tab, wrapping around at either end, or nil if there isn't one
+*/
+func (m TabContainerModel) getNeighborTab(direction int) *con.Component {
+	count := len(m.components)
+	activeIdx := m.GetActiveTabIndex()
+	for offset := 1; offset < count; offset++ {
+		if neighbor := m.components[((activeIdx+offset*direction)%count+count)%count]; !neighbor.IsHidden() {
+			return neighbor
+		}
+	}
+	return nil
+}
+
+/*
+Switches to the closest tab to the right of the active tab that isn't hidden if the active
+tab was hidden (the user-facing hidden flag is left alone, see con.Component.SetHidden)
+*/
+func (m TabContainerModel) ensureVisibleTab() {
+	if active := m.GetActiveTab(); active != nil && active.IsHidden() {
+		if neighbor := m.getNeighborTab(1); neighbor != nil {
+			*m.active = slices.Index(m.components, neighbor)
+		}
+	}
+}
+
+/*
+Moves the given tab to the given index, shifting the tabs in between. The active tab stays active
+*/
+func (m TabContainerModel) MoveTab(component *con.Component, newIdx int) TabContainerModel {
+	idx := slices.Index(m.components, component)
+	if idx < 0 || newIdx < 0 || newIdx >= len(m.components) || idx == newIdx {
+		return m
+	}
+	active := m.GetActiveTab()
+	m.components = con.Splice(con.Splice(m.components, idx, idx+1), newIdx, newIdx, component)
+	*m.active = slices.Index(m.components, active)
+	m.SetFocusHandler(m.GetFocusHandler())
+	return m
+}
+
+func (m *TabContainerModel) SetFocusHandler(handler con.FocusHandler) {
+	// the inactive tabs can't receive focus
+	m.focusHandler = handler.SetComponentDelegate(m.GetVisibleComponents)
+}
+
+func (m TabContainerModel) GetFocusHandler() con.FocusHandler {
+	return m.focusHandler
+}
+
+/*
+Returns the TabContainerModel with the given component as its focused component (see con.FocusSyncable)
+*/
+func (m TabContainerModel) SyncFocus(focused *con.Component) tea.Model {
+	if m.GetFocusHandler() != nil {
+		m.SetFocusHandler(m.GetFocusHandler().SetFocusedComponent(focused))
+	}
+	return m
+}
+
+/*
+Moves focus into the active tab if the focused component isn't in it (because the active
+tab was changed, e.g. by an Action). Focus that was given to a component outside of the
+TabContainerModel (by a parent container) is left alone
+*/
+func (m *TabContainerModel) ensureValidFocus() {
+	con.EnsureValidFocus(m, m.GetVisibleComponents())
+}
+
+/*
+Returns the actions of the active tab, followed by the actions for switching and
+reordering tabs. Tab actions whose shortcuts are already used by the active tab's
+actions (e.g. those of a nested TabContainerModel) are left out, so that only the
+innermost TabContainerModel responds to them
+*/
+func (m TabContainerModel) GetActions() (output []con.Action) {
+	if active := m.GetActiveTab(); active != nil {
+		output = append(output, active.GetActions()...)
+	}
+	for _, action := range m.getTabActions() {
+		if !slices.ContainsFunc(output, func(other con.Action) bool {
+			return action.GetShortcut() != "" && other.GetShortcut() == action.GetShortcut()
+		}) {
+			output = append(output, action)
+		}
+	}
+	return output
+}
+
+/*
+Returns the current border style of the given component
+*/
+func (m TabContainerModel) GetComponentStyle(component *con.Component) lipgloss.Style {
+	if component == nil {
+		return con.NO_BORDER_STYLE
+	}
+	return component.GetCurrentBorderStyle(m.GetFocusHandler().GetFocusedComponent() == component)
+}
+
+/*
+Returns the area below the tab strip, where the active tab is rendered
+*/
+func (m TabContainerModel) getContentBounds() con.Rectangle {
+	return con.Rectangle{
+		Y:      TAB_STRIP_HEIGHT,
+		Width:  m.size.Width,
+		Height: max(0, m.size.Height-TAB_STRIP_HEIGHT),
+	}
+}
+
+/*
+Returns the size the tabs take up within the given constraint. The TabContainerModel needs enough
+space below the tab strip for the smallest size of any of its tabs (so that selecting another tab
+doesn't crush it), and can make use of enough for the largest size of any of them
+*/
+func (m TabContainerModel) GetContentSize(constraint tea.WindowSizeMsg) con.ContentSize {
+	var output con.ContentSize
+	if len(m.components) == 0 {
+		output.Maximum = tea.WindowSizeMsg{Width: math.MaxInt, Height: math.MaxInt}
+	}
+	for _, component := range m.components {
+		// hidden tabs report a size range of zero
+		minimum, maximum := component.GetLayoutBounds(component.GetBorderStyle())
+		output.Minimum.Width = max(output.Minimum.Width, minimum.Width)
+		output.Minimum.Height = max(output.Minimum.Height, minimum.Height)
+		output.Maximum.Width = max(output.Maximum.Width, maximum.Width)
+		output.Maximum.Height = max(output.Maximum.Height, maximum.Height)
+	}
+	output.Minimum.Height += TAB_STRIP_HEIGHT
+	output.Maximum.Height = utils.SaturatingSum(output.Maximum.Height, TAB_STRIP_HEIGHT)
+	output.Preferred = tea.WindowSizeMsg{
+		Width:  min(constraint.Width, output.Maximum.Width),
+		Height: min(constraint.Height, output.Maximum.Height),
+	}
+	return output
+}
+
+/*
+Resizes the active tab to fill the area below the tab strip, as far as its minimum and
+maximum dimensions allow (the other tabs are resized when they're selected)
+*/
+func (m *TabContainerModel) ResizeComponents(containerSize tea.WindowSizeMsg) tea.Cmd {
+	m.size = containerSize
+	area := m.getContentBounds()
+	var cmds []tea.Cmd
+	for _, component := range m.GetVisibleComponents() {
+		style := m.GetComponentStyle(component)
+		size := component.GetLayoutClampedSize(tea.WindowSizeMsg{Width: area.Width, Height: area.Height}, style)
+		model, cmd := component.GetModel().Update(tea.WindowSizeMsg{
+			Width:  size.Width - style.GetHorizontalFrameSize(),
+			Height: size.Height - style.GetVerticalFrameSize(),
+		})
+		component.SetSize(size)
+		component.SetModel(model)
+		component.SetLayout(con.Rectangle{X: area.X, Y: area.Y, Width: size.Width, Height: size.Height}, style)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+/*
+Returns the size of the area occupied by the tab strip and the active tab
+*/
+func (m TabContainerModel) GetFullContainerSize() (output tea.WindowSizeMsg) {
+	output.Width = m.size.Width
+	output.Height = TAB_STRIP_HEIGHT
+	if active := m.GetActiveTab(); active != nil {
+		output.Width = max(output.Width, active.GetSize().Width)
+		output.Height += active.GetSize().Height
+	}
+	return
+}
+
+/*
+Switches to the tab whose label was clicked, or routes the given tea.MouseMsg to the
+active tab (with its coordinates translated into the tab's space). Clicks in the
+active tab move focus to the most deeply nested focusable component under the cursor
+*/
+func (m *TabContainerModel) handleMouseMsg(msg tea.MouseMsg) tea.Cmd {
+	if msg.Y < TAB_STRIP_HEIGHT {
+		target := m.getTabAt(msg.X)
+		if !con.IsFocusingMouseMsg(msg) || target == nil || target == m.GetActiveTab() {
+			return nil
+		}
+		// switching tabs would take focus out of a focus scope in the active tab
+		if con.GetActiveFocusTrap(m.GetVisibleComponents()) != nil {
+			return nil
+		}
+		action := m.newSelectTabAction("select tab", "Switch to the clicked tab", "", func(TabContainerModel) *con.Component { return target })
+		return m.executeAction(action)
+	}
+	return con.RouteMouseMsg(m, msg, nil)
+}
+
+/*
+Executes the given tab Action on the TabContainerModel, lays the newly active tab out and reports
+the Action as executed (so that it can be undone)
+*/
+func (m *TabContainerModel) executeAction(action con.Action) tea.Cmd {
+	action.Execute()
+	con.ApplyChanges(m.changes, m)
+	m.ensureValidFocus()
+	return tea.Batch(con.RelayoutContainer(m, m.size), con.ReportExecutedAction(action))
+}
+
+func (m TabContainerModel) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, component := range m.GetComponents() {
+		cmds = append(cmds, component.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+/*
+Updates the TabContainerModel and its components with the given message, then sends the components the
+lifecycle messages (see con.DeliverLifecycle) and delivers focus (see con.DeliverFocus)
+in case the message laid them out, showed or hid them, or changed which one has focus
+*/
+func (m TabContainerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return con.UpdateContainer(m, msg, m.update)
+}
+
+func (m TabContainerModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	con.ApplyChanges(m.changes, &m)
+	// the active tab may have been changed (e.g. by an Action) or hidden since the last message
+	m.ensureVisibleTab()
+	m.ensureValidFocus()
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if action := m.getTabKeyAction(msg.String()); action != nil {
+			return m, (&m).executeAction(action)
+		}
+		if handler, isFocusKey := con.HandleNestedFocusKey(m.GetVisibleComponents(), m.GetFocusHandler(), msg.String()); isFocusKey {
+			m.SetFocusHandler(handler)
+			return m, con.GetFocusOverlayCmd(m.GetFocusHandler())
+		}
+		return m, con.ForwardKey(m.GetComponents(), m.GetFocusHandler(), msg)
+	case tea.WindowSizeMsg:
+		return m, (&m).ResizeComponents(msg)
+	case con.RelayoutMsg:
+		// the active tab may have been changed by an Action the action bar ran
+		m.ensureValidFocus()
+		return m, con.RelayoutContainer(&m, m.size)
+	case tea.MouseMsg:
+		return m, (&m).handleMouseMsg(msg)
+	case con.ComponentsMsg:
+		return m, (&m).handleComponentsMsg(msg)
+	case con.SelectedMsg:
+		return m, con.HandleSelectedMsg(m.GetComponents(), msg)
+	}
+	for _, component := range m.GetComponents() {
+		cmds = append(cmds, component.Update(msg))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m TabContainerModel) ViewComponent(component *con.Component) string {
+	if m.GetFocusHandler().GetFocusedComponent() == component {
+		return component.RenderFocused()
+	}
+	return component.RenderBlurred()
+}
+
+/*
+Returns a number that changes whenever the TabContainerModel's View would return something
+different: when its tab strip changes, or when its active tab would be rendered differently
+*/
+func (m TabContainerModel) GetRenderRevision() uint64 {
+	hasher := con.NewRenderHasher().AddString(m.renderTabStrip())
+	if active := m.GetActiveTab(); active != nil {
+		hasher.AddComponent(active, active == m.GetFocusHandler().GetFocusedComponent())
+	}
+	return hasher.AddFocusHandler(m.GetFocusHandler()).Sum()
+}
+
+/*
+Renders the tab strip above the active tab
+*/
+func (m TabContainerModel) View() string {
+	// the active tab isn't joined with the tab strip again unless one of them changed
+	return m.viewCache.Get(m.GetRenderRevision(), m.view)
+}
+
+func (m TabContainerModel) view() string {
+	strip := m.renderTabStrip()
+	active := m.GetActiveTab()
+	if active == nil {
+		return strip
+	}
+	return con.PlaceFocusOverlay(lipgloss.JoinVertical(lipgloss.Left, strip, m.ViewComponent(active)), m.GetFocusHandler())
+}
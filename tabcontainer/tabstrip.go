@@ -0,0 +1,93 @@
+package tabcontainer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/argotnaut/vanitea/colors"
+	con "github.com/argotnaut/vanitea/container"
+)
+
+const (
+	// The number of rows taken up by the tab strip
+	TAB_STRIP_HEIGHT = 1
+	// Drawn between adjacent tab labels
+	TAB_SEPARATOR = "│"
+)
+
+var ACTIVE_TAB_STYLE = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(colors.FOCUSED_BORDER))
+
+var INACTIVE_TAB_STYLE = lipgloss.NewStyle().Foreground(lipgloss.Color(colors.UNFOCUSED_BORDER))
+
+/*
+Returns the title of the given tab, or its position if it doesn't have one
+*/
+func (m TabContainerModel) getTabTitle(idx int) string {
+	component := m.GetComponent(idx)
+	if component == nil || strings.TrimSpace(component.GetTitle()) == "" {
+		return fmt.Sprintf("tab %d", idx+1)
+	}
+	return strings.TrimSpace(component.GetTitle())
+}
+
+/*
+Returns the text of the given tab's label, made from its title and its shortcut
+*/
+func (m TabContainerModel) getTabLabel(idx int) string {
+	component := m.GetComponent(idx)
+	if component == nil {
+		return ""
+	}
+	label := m.getTabTitle(idx)
+	if shortcut := strings.TrimSpace(component.GetShortcut()); shortcut != "" {
+		label += " (" + shortcut + ")"
+	}
+	return " " + label + " "
+}
+
+/*
+Returns the tab whose label is at the given column of the tab strip, or nil if there isn't one
+*/
+func (m TabContainerModel) getTabAt(x int) *con.Component {
+	start := 0
+	for idx, component := range m.components {
+		if component.IsHidden() {
+			continue
+		}
+		end := start + lipgloss.Width(m.getTabLabel(idx))
+		if x >= start && x < end {
+			return component
+		}
+		start = end + lipgloss.Width(TAB_SEPARATOR)
+	}
+	return nil
+}
+
+/*
+Renders the labels of all of the tabs that aren't hidden in a single row (truncated to
+the width of the TabContainerModel), highlighting the label of the active tab
+*/
+func (m TabContainerModel) renderTabStrip() string {
+	active := m.GetActiveTab()
+	separator := INACTIVE_TAB_STYLE.Render(TAB_SEPARATOR)
+	var output strings.Builder
+	for idx, component := range m.components {
+		if component.IsHidden() {
+			continue
+		}
+		if output.Len() > 0 {
+			output.WriteString(separator)
+		}
+		style := INACTIVE_TAB_STYLE
+		if component == active {
+			style = ACTIVE_TAB_STYLE
+		}
+		output.WriteString(style.Render(m.getTabLabel(idx)))
+	}
+	if m.size.Width < 1 {
+		return output.String()
+	}
+	return lipgloss.NewStyle().MaxWidth(m.size.Width).Width(m.size.Width).Render(output.String())
+}
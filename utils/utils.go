@@ -36,6 +36,20 @@ func ClampInt(input int, minimum int, maximum int) int {
 	return max(minimum, min(input, maximum))
 }
 
+/*
+Returns the sum of the given non-negative ints, or math.MaxInt if the sum would
+overflow (so that unbounded sizes stay unbounded when they're added to)
+*/
+func SaturatingSum(values ...int) (output int) {
+	for _, value := range values {
+		if value > math.MaxInt-output {
+			return math.MaxInt
+		}
+		output += value
+	}
+	return output
+}
+
 /*
 Returns the input int between the two boundaries and wraps
 it if it's out of the given bounds